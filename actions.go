@@ -0,0 +1,232 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// A WorkflowRun is a single run of a GitHub Actions workflow.
+type WorkflowRun struct {
+	ID         int64
+	Owner      string
+	Repo       string
+	Name       string
+	WorkflowID int64
+	Number     int
+	Attempt    int
+	Event      string
+	Status     string // queued, in_progress, completed, ...
+	Conclusion string // success, failure, cancelled, ...; empty until completed
+	HeadBranch string
+	HeadCommit string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	URL        string
+}
+
+type workflowRunJSON struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	WorkflowID int64     `json:"workflow_id"`
+	RunNumber  int       `json:"run_number"`
+	RunAttempt int       `json:"run_attempt"`
+	Event      string    `json:"event"`
+	Status     string    `json:"status"`
+	Conclusion string    `json:"conclusion"`
+	HeadBranch string    `json:"head_branch"`
+	HeadSHA    string    `json:"head_sha"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	HTMLURL    string    `json:"html_url"`
+}
+
+func toWorkflowRun(org, repo string, j *workflowRunJSON) *WorkflowRun {
+	return &WorkflowRun{
+		ID:         j.ID,
+		Owner:      org,
+		Repo:       repo,
+		Name:       j.Name,
+		WorkflowID: j.WorkflowID,
+		Number:     j.RunNumber,
+		Attempt:    j.RunAttempt,
+		Event:      j.Event,
+		Status:     j.Status,
+		Conclusion: j.Conclusion,
+		HeadBranch: j.HeadBranch,
+		HeadCommit: j.HeadSHA,
+		CreatedAt:  j.CreatedAt,
+		UpdatedAt:  j.UpdatedAt,
+		URL:        j.HTMLURL,
+	}
+}
+
+// WorkflowRunOptions restricts the runs returned by WorkflowRuns.
+// Empty fields impose no restriction.
+type WorkflowRunOptions struct {
+	Workflow string // workflow file name (such as "test.yml") or ID
+	Branch   string
+	Event    string
+	Status   string // status or conclusion, such as "completed" or "failure"
+	Commit   string // head commit hash
+}
+
+// WorkflowRuns returns the workflow runs in the repository org/repo,
+// most recent first. If opts is non-nil, it restricts the runs returned.
+func (c *Client) WorkflowRuns(org, repo string, opts *WorkflowRunOptions) ([]*WorkflowRun, error) {
+	path := "/repos/" + org + "/" + repo + "/actions/runs"
+	q := url.Values{}
+	if opts != nil {
+		if opts.Workflow != "" {
+			path = "/repos/" + org + "/" + repo + "/actions/workflows/" + url.PathEscape(opts.Workflow) + "/runs"
+		}
+		if opts.Branch != "" {
+			q.Set("branch", opts.Branch)
+		}
+		if opts.Event != "" {
+			q.Set("event", opts.Event)
+		}
+		if opts.Status != "" {
+			q.Set("status", opts.Status)
+		}
+		if opts.Commit != "" {
+			q.Set("head_sha", opts.Commit)
+		}
+	}
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	list, err := restCollect[*workflowRunJSON](c, path, "workflow_runs")
+	var runs []*WorkflowRun
+	for _, j := range list {
+		runs = append(runs, toWorkflowRun(org, repo, j))
+	}
+	return runs, err
+}
+
+// WorkflowRun returns the workflow run with the given ID.
+func (c *Client) WorkflowRun(org, repo string, id int64) (*WorkflowRun, error) {
+	var j workflowRunJSON
+	if err := c.rest("GET", fmt.Sprintf("/repos/%s/%s/actions/runs/%d", org, repo, id), nil, &j); err != nil {
+		return nil, err
+	}
+	return toWorkflowRun(org, repo, &j), nil
+}
+
+// A WorkflowJob is a single job in a workflow run.
+type WorkflowJob struct {
+	ID          int64
+	RunID       int64
+	Name        string
+	Status      string
+	Conclusion  string
+	StartedAt   time.Time
+	CompletedAt time.Time
+	URL         string
+	Steps       []*WorkflowStep
+}
+
+// A WorkflowStep is a single step in a workflow job.
+type WorkflowStep struct {
+	Number      int       `json:"number"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+type workflowJobJSON struct {
+	ID          int64           `json:"id"`
+	RunID       int64           `json:"run_id"`
+	Name        string          `json:"name"`
+	Status      string          `json:"status"`
+	Conclusion  string          `json:"conclusion"`
+	StartedAt   time.Time       `json:"started_at"`
+	CompletedAt time.Time       `json:"completed_at"`
+	HTMLURL     string          `json:"html_url"`
+	Steps       []*WorkflowStep `json:"steps"`
+}
+
+// WorkflowJobs returns the jobs in the most recent attempt of the workflow run.
+func (c *Client) WorkflowJobs(run *WorkflowRun) ([]*WorkflowJob, error) {
+	path := fmt.Sprintf("/repos/%s/%s/actions/runs/%d/jobs", run.Owner, run.Repo, run.ID)
+	list, err := restCollect[*workflowJobJSON](c, path, "jobs")
+	var jobs []*WorkflowJob
+	for _, j := range list {
+		jobs = append(jobs, &WorkflowJob{
+			ID:          j.ID,
+			RunID:       j.RunID,
+			Name:        j.Name,
+			Status:      j.Status,
+			Conclusion:  j.Conclusion,
+			StartedAt:   j.StartedAt,
+			CompletedAt: j.CompletedAt,
+			URL:         j.HTMLURL,
+			Steps:       j.Steps,
+		})
+	}
+	return jobs, err
+}
+
+// An Artifact is a file produced by a workflow run.
+type Artifact struct {
+	ID        int64
+	Owner     string
+	Repo      string
+	RunID     int64
+	Name      string
+	Size      int64
+	Expired   bool
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+type artifactJSON struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	SizeInBytes int64     `json:"size_in_bytes"`
+	Expired     bool      `json:"expired"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	WorkflowRun struct {
+		ID int64 `json:"id"`
+	} `json:"workflow_run"`
+}
+
+// Artifacts returns the artifacts produced by the workflow run.
+func (c *Client) Artifacts(run *WorkflowRun) ([]*Artifact, error) {
+	path := fmt.Sprintf("/repos/%s/%s/actions/runs/%d/artifacts", run.Owner, run.Repo, run.ID)
+	list, err := restCollect[*artifactJSON](c, path, "artifacts")
+	var arts []*Artifact
+	for _, j := range list {
+		arts = append(arts, &Artifact{
+			ID:        j.ID,
+			Owner:     run.Owner,
+			Repo:      run.Repo,
+			RunID:     j.WorkflowRun.ID,
+			Name:      j.Name,
+			Size:      j.SizeInBytes,
+			Expired:   j.Expired,
+			CreatedAt: j.CreatedAt,
+			ExpiresAt: j.ExpiresAt,
+		})
+	}
+	return arts, err
+}
+
+// DownloadArtifact returns the content of the artifact, a zip file.
+func (c *Client) DownloadArtifact(a *Artifact) ([]byte, error) {
+	if a.Expired {
+		return nil, fmt.Errorf("artifact %s (%d) has expired", a.Name, a.ID)
+	}
+	_, data, err := c.restBody("GET", fmt.Sprintf("/repos/%s/%s/actions/artifacts/%d/zip", a.Owner, a.Repo, a.ID), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
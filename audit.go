@@ -0,0 +1,101 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// An AuditEntry describes a successful mutation made by a Client,
+// as passed to the hook set by [Client.WithAudit].
+type AuditEntry struct {
+	Name    string   // GraphQL mutation, as in "addComment", or REST method and path, as in "PATCH /gists/123"
+	IDs     []string // IDs of the objects changed: the values of ID variables in a GraphQL mutation
+	Summary string   // human-readable summary, as in "closeIssue(Issue=I_kwDOAWBuHs5Xz5bT)"
+	Query   string   // GraphQL mutation text; empty for REST requests
+	Vars    Vars     // GraphQL variables; nil for REST requests
+	Body    any      // REST request body; nil for GraphQL requests
+}
+
+// WithAudit returns a shallow copy of c that calls f after each successful mutation,
+// for example to write an audit trail of every change a program makes.
+// Mutations include GraphQL mutations and REST requests other than GET and HEAD.
+// Mutations recorded by a dry run (see [Client.WithDryRun]) are not audited,
+// since they are not made.
+// Passing a nil f removes any existing hook.
+func (c *Client) WithAudit(f func(*AuditEntry)) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.audit = f
+	return c2
+}
+
+// auditGraphQL calls the audit hook, if any, for the GraphQL mutation query.
+func (c *Client) auditGraphQL(query string, vars Vars) {
+	if c.audit == nil {
+		return
+	}
+	name := mutationName(query)
+	c.audit(&AuditEntry{
+		Name:    name,
+		IDs:     mutationIDs(query, vars),
+		Summary: summarize(name, vars),
+		Query:   query,
+		Vars:    vars,
+	})
+}
+
+// auditREST calls the audit hook, if any, for the REST request.
+func (c *Client) auditREST(method, path string, body any) {
+	if c.audit == nil || method == "GET" || method == "HEAD" {
+		return
+	}
+	name := method + " " + path
+	c.audit(&AuditEntry{Name: name, Summary: name, Body: body})
+}
+
+var idVarRE = regexp.MustCompile(`\$(\w+)\s*:\s*\[?\s*ID\b`)
+
+// mutationIDs returns the values of the variables declared
+// with type ID, ID!, or [ID!] in the GraphQL mutation query.
+func mutationIDs(query string, vars Vars) []string {
+	var ids []string
+	for _, m := range idVarRE.FindAllStringSubmatch(query, -1) {
+		switch v := vars[m[1]].(type) {
+		case string:
+			if v != "" {
+				ids = append(ids, v)
+			}
+		case []string:
+			ids = append(ids, v...)
+		}
+	}
+	return ids
+}
+
+// summarize returns a one-line summary of a GraphQL operation with the given variables.
+// Long values, such as comment bodies, are truncated.
+func summarize(name string, vars Vars) string {
+	var keys []string
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var args []string
+	for _, k := range keys {
+		v := fmt.Sprint(vars[k])
+		if s, ok := vars[k].(string); ok && (len(s) > 40 || strings.ContainsAny(s, " \t\n\",()")) {
+			if len(s) > 40 {
+				s = s[:37] + "..."
+			}
+			v = fmt.Sprintf("%q", s)
+		}
+		args = append(args, k+"="+v)
+	}
+	return name + "(" + strings.Join(args, ", ") + ")"
+}
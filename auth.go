@@ -0,0 +1,294 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// A TokenSource supplies GitHub access tokens.
+// A Client created with [NewTokenSourceClient] calls Token before each request,
+// so a TokenSource can refresh short-lived tokens, such as GitHub App installation tokens.
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// NewTokenSourceClient returns a new client that authenticates
+// each request using a token obtained from ts.
+func NewTokenSourceClient(ts TokenSource) *Client {
+	c := NewClient("")
+	c.ts = ts
+	return c
+}
+
+// authToken returns the token to use for the next request.
+func (c *Client) authToken() (string, error) {
+	if c.ts != nil {
+		token, err := c.ts.Token()
+		if err != nil {
+			return "", fmt.Errorf("github: obtaining token: %w", err)
+		}
+		return token, nil
+	}
+	return c.token, nil
+}
+
+// dialToken returns the token that Dial and DialEnterprise should use
+// for user on host (github.com or a GitHub Enterprise Server host name).
+// It looks first in $HOME/.netrc and then in the gh command's configuration.
+func dialToken(host, user string) (string, error) {
+	netrcHost := host
+	if host == "github.com" {
+		netrcHost = "api.github.com"
+	}
+	_, passwd, err := netrcAuth(netrcHost, user)
+	if err == nil {
+		return passwd, nil
+	}
+	token, ghErr := ghToken(host, user)
+	if ghErr == nil {
+		return token, nil
+	}
+	return "", errors.Join(err, ghErr)
+}
+
+// ghToken returns the token stored by the gh command (GitHub's CLI)
+// for user on host, or for gh's active user if user is the empty string.
+// It reads gh's hosts.yml and, if the token is not stored there
+// (newer versions of gh keep it in the system keyring),
+// runs "gh auth token".
+func ghToken(host, user string) (string, error) {
+	if data, err := os.ReadFile(ghHostsFile()); err == nil {
+		cfg := parseSimpleYAML(data)
+		var token string
+		if user == "" || cfg[host+".user"] == user {
+			token = cfg[host+".oauth_token"]
+		}
+		if token == "" && user != "" {
+			token = cfg[host+".users."+user+".oauth_token"]
+		}
+		if token != "" {
+			return token, nil
+		}
+	}
+
+	args := []string{"auth", "token", "--hostname", host}
+	if user != "" {
+		args = append(args, "--user", user)
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command("gh", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if stderr.Len() > 0 {
+			err = fmt.Errorf("%v\n%s", err, bytes.TrimSpace(stderr.Bytes()))
+		}
+		return "", fmt.Errorf("cannot find gh token for %s: gh auth token: %v", host, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ghHostsFile returns the name of the gh command's hosts.yml file.
+func ghHostsFile() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "hosts.yml")
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh", "hosts.yml")
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("AppData"); dir != "" {
+			return filepath.Join(dir, "GitHub CLI", "hosts.yml")
+		}
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "gh", "hosts.yml")
+}
+
+// parseSimpleYAML parses the simple YAML used in gh's hosts.yml,
+// which contains only nested maps with string values.
+// It returns a map from dotted key paths, like "github.com.oauth_token", to values.
+func parseSimpleYAML(data []byte) map[string]string {
+	type level struct {
+		indent int
+		key    string
+	}
+	m := make(map[string]string)
+	var stack []level
+	for _, line := range strings.Split(string(data), "\n") {
+		t := strings.TrimLeft(line, " ")
+		if t == "" || strings.HasPrefix(t, "#") {
+			continue
+		}
+		indent := len(line) - len(t)
+		key, val, ok := strings.Cut(t, ":")
+		if !ok {
+			continue
+		}
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		var path []string
+		for _, l := range stack {
+			path = append(path, l.key)
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"'`)
+		path = append(path, key)
+		val = strings.Trim(strings.TrimSpace(val), `"'`)
+		if val == "" {
+			stack = append(stack, level{indent, key})
+			continue
+		}
+		m[strings.Join(path, ".")] = val
+	}
+	return m
+}
+
+// A TokenInfo describes the token used by a Client, as reported by [Client.Verify].
+type TokenInfo struct {
+	Login   string    // login of the authenticated user or app
+	Type    TokenType // kind of token
+	Scopes  []string  // OAuth scopes granted to a classic token
+	Missing []string  // requested scopes not granted to a classic token
+}
+
+// A TokenType identifies a kind of GitHub token.
+type TokenType string
+
+const (
+	ClassicToken     TokenType = "classic"      // personal access token (classic), ghp_
+	FineGrainedToken TokenType = "fine-grained" // fine-grained personal access token, github_pat_
+	OAuthToken       TokenType = "oauth"        // OAuth app token, gho_
+	AppUserToken     TokenType = "app-user"     // GitHub App user-to-server token, ghu_
+	AppInstallToken  TokenType = "app"          // GitHub App installation token, ghs_
+	UnknownTokenType TokenType = "unknown"
+)
+
+// Verify checks that c's token is valid, by making a cheap authenticated request,
+// and reports the token's login and type.
+// If scopes are given, as in "repo" or "read:org", Verify also reports
+// which of them a classic or OAuth token lacks, taking into account
+// that broader scopes imply narrower ones ("repo" implies "public_repo").
+// GitHub does not report permissions for fine-grained or GitHub App tokens,
+// so for those Missing is always empty, and a missing permission
+// shows up only as an error from the request that needs it.
+//
+// Verify is meant to be called once at startup,
+// so that a misconfigured token is reported clearly
+// instead of as a confusing error partway through a run.
+func (c *Client) Verify(scopes ...string) (*TokenInfo, error) {
+	token, err := c.authToken()
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, fmt.Errorf("github: no token")
+	}
+
+	var user struct {
+		Login string `json:"login"`
+	}
+	resp, data, err := c.restBody("GET", "/user", "", nil)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 401 {
+			return nil, fmt.Errorf("github: invalid or expired token")
+		}
+		if resp != nil && resp.StatusCode == 403 && strings.HasPrefix(token, "ghs_") {
+			// Installation tokens cannot call /user.
+			return &TokenInfo{Type: AppInstallToken}, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &user); err != nil {
+		return nil, fmt.Errorf("parsing reply: %v", err)
+	}
+
+	info := &TokenInfo{Login: user.Login, Type: tokenType(token)}
+	h := resp.Header.Get("X-OAuth-Scopes") // set only for classic and OAuth tokens
+	for _, s := range strings.Split(h, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			info.Scopes = append(info.Scopes, s)
+		}
+	}
+	if info.Type == UnknownTokenType && h != "" {
+		info.Type = ClassicToken
+	}
+	if info.Type == ClassicToken || info.Type == OAuthToken {
+		for _, s := range scopes {
+			if !hasScope(info.Scopes, s) {
+				info.Missing = append(info.Missing, s)
+			}
+		}
+	}
+	return info, nil
+}
+
+// tokenType returns the type of token, determined by its prefix.
+// See https://github.blog/2021-04-05-behind-githubs-new-authentication-token-formats/.
+func tokenType(token string) TokenType {
+	switch {
+	case strings.HasPrefix(token, "ghp_"):
+		return ClassicToken
+	case strings.HasPrefix(token, "github_pat_"):
+		return FineGrainedToken
+	case strings.HasPrefix(token, "gho_"):
+		return OAuthToken
+	case strings.HasPrefix(token, "ghu_"):
+		return AppUserToken
+	case strings.HasPrefix(token, "ghs_"):
+		return AppInstallToken
+	}
+	return UnknownTokenType
+}
+
+// impliedScopes maps each OAuth scope to the broader scopes that imply it.
+// See https://docs.github.com/en/apps/oauth-apps/building-oauth-apps/scopes-for-oauth-apps.
+var impliedScopes = map[string][]string{
+	"repo:status":               {"repo"},
+	"repo_deployment":           {"repo"},
+	"public_repo":               {"repo"},
+	"repo:invite":               {"repo"},
+	"security_events":           {"repo"},
+	"write:org":                 {"admin:org"},
+	"read:org":                  {"write:org", "admin:org"},
+	"manage_runners:org":        {"admin:org"},
+	"write:public_key":          {"admin:public_key"},
+	"read:public_key":           {"write:public_key", "admin:public_key"},
+	"write:repo_hook":           {"admin:repo_hook"},
+	"read:repo_hook":            {"write:repo_hook", "admin:repo_hook"},
+	"read:user":                 {"user"},
+	"user:email":                {"user"},
+	"user:follow":               {"user"},
+	"read:packages":             {"write:packages"},
+	"read:discussion":           {"write:discussion"},
+	"read:gpg_key":              {"write:gpg_key", "admin:gpg_key"},
+	"write:gpg_key":             {"admin:gpg_key"},
+	"read:project":              {"project"},
+	"read:audit_log":            {"admin:enterprise"},
+	"manage_billing:enterprise": {"admin:enterprise"},
+}
+
+// hasScope reports whether the granted scopes include or imply scope.
+func hasScope(granted []string, scope string) bool {
+	if slices.Contains(granted, scope) {
+		return true
+	}
+	for _, s := range impliedScopes[scope] {
+		if slices.Contains(granted, s) {
+			return true
+		}
+	}
+	return false
+}
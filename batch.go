@@ -0,0 +1,295 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// A Batch combines several independent GraphQL queries into a single request,
+// using aliases to keep their results apart.
+// For example, to fetch a repository's labels and milestones in one round trip:
+//
+//	b := c.NewBatch()
+//	var labels, milestones schema.Query
+//	lq := b.Query(labelsQuery, Vars{"Org": org, "Repo": repo}, &labels)
+//	mq := b.Query(milestonesQuery, Vars{"Org": org, "Repo": repo}, &milestones)
+//	if err := b.Do(); err != nil {
+//		return err
+//	}
+//	if lq.Err != nil {
+//		...
+//	}
+//
+// Each query is written as if it were sent by itself,
+// with its own variable declarations;
+// the Batch renames variables and adds aliases as needed.
+// Batches do not handle pagination: each query's results
+// are limited to a single page.
+//
+// GitHub limits the size and cost of a single request, so batches
+// should be kept to tens of queries, not thousands.
+type Batch struct {
+	c       *Client
+	queries []*BatchQuery
+}
+
+// A BatchQuery is a single query in a [Batch].
+type BatchQuery struct {
+	// Err is the error for this query, set by [Batch.Do].
+	// It is nil if the query succeeded.
+	// If the query failed but others in the batch succeeded,
+	// Err is a [GraphQLErrors] holding only this query's errors.
+	Err error
+
+	query  string
+	vars   Vars
+	reply  any
+	prefix string
+	fields []string // top-level fields (or aliases) in the original query
+}
+
+// NewBatch returns a new, empty batch of queries to run using c.
+func (c *Client) NewBatch() *Batch {
+	return &Batch{c: c}
+}
+
+// Query adds a query with the bound variables to the batch.
+// When the batch is run, the query's results are decoded into reply,
+// which is typically a *[schema.Query].
+// Only queries can be batched, not mutations.
+func (b *Batch) Query(query string, vars Vars, reply any) *BatchQuery {
+	q := &BatchQuery{query: query, vars: vars, reply: reply}
+	b.queries = append(b.queries, q)
+	return q
+}
+
+// Do runs all the queries added to the batch in a single request.
+// It returns an error only if the request as a whole fails
+// (or a query cannot be parsed);
+// errors in individual queries are reported in each [BatchQuery.Err].
+func (b *Batch) Do() error {
+	if len(b.queries) == 0 {
+		return nil
+	}
+	var decls []string
+	var body strings.Builder
+	vars := make(Vars)
+	for i, q := range b.queries {
+		q.prefix = fmt.Sprintf("b%d_", i)
+		d, sel, fields, err := aliasQuery(q.query, q.prefix)
+		if err != nil {
+			return err
+		}
+		q.fields = fields
+		if d != "" {
+			decls = append(decls, d)
+		}
+		body.WriteString(sel)
+		body.WriteString("\n")
+		for k, v := range q.vars {
+			vars[q.prefix+k] = v
+		}
+	}
+	graphql := "query"
+	if len(decls) > 0 {
+		graphql += "(" + strings.Join(decls, ", ") + ")"
+	}
+	graphql += " {\n" + body.String() + "}\n"
+
+	var reply map[string]json.RawMessage
+	err := b.c.graphQL(graphql, vars, &reply)
+	gerrs, ok := err.(GraphQLErrors)
+	if err != nil && (!ok || !gerrs.Partial()) {
+		for _, q := range b.queries {
+			q.Err = err
+		}
+		return err
+	}
+
+	for _, q := range b.queries {
+		var errs GraphQLErrors
+		for _, e := range gerrs {
+			if len(e.Path) == 0 {
+				// Not specific to one query.
+				errs = append(errs, e)
+				continue
+			}
+			if s, ok := e.Path[0].(string); ok && strings.HasPrefix(s, q.prefix) {
+				e1 := *e
+				e1.Path = append([]any{strings.TrimPrefix(s, q.prefix)}, e.Path[1:]...)
+				errs = append(errs, &e1)
+			}
+		}
+		if len(errs) > 0 {
+			q.Err = errs
+		}
+		data := make(map[string]json.RawMessage)
+		for _, f := range q.fields {
+			if v, ok := reply[q.prefix+f]; ok {
+				data[f] = v
+			}
+		}
+		js, err := json.Marshal(data)
+		if err == nil {
+			err = json.Unmarshal(js, q.reply)
+		}
+		if err != nil && q.Err == nil {
+			q.Err = fmt.Errorf("parsing reply: %v", err)
+		}
+	}
+	return nil
+}
+
+// aliasQuery rewrites query for inclusion in a batch,
+// adding prefix to each variable name and to the alias of each top-level field.
+// It returns the rewritten variable declarations (without parentheses),
+// the rewritten top-level selections (without braces),
+// and the names under which the original query would have returned
+// its top-level fields.
+func aliasQuery(query, prefix string) (decls, sel string, fields []string, err error) {
+	q := strings.TrimSpace(query)
+	if rest, ok := strings.CutPrefix(q, "query"); ok {
+		q = strings.TrimSpace(rest)
+		i := 0
+		for i < len(q) && isNameChar(q[i]) {
+			i++
+		}
+		q = strings.TrimSpace(q[i:])
+		if strings.HasPrefix(q, "(") {
+			end := matching(q, 0)
+			if end < 0 {
+				return "", "", nil, fmt.Errorf("batch: malformed query: %s", query)
+			}
+			decls = strings.TrimSpace(renameVars(q[1:end], prefix))
+			q = strings.TrimSpace(q[end+1:])
+		}
+	}
+	if !strings.HasPrefix(q, "{") || !strings.HasSuffix(q, "}") {
+		return "", "", nil, fmt.Errorf("batch: can only batch queries: %s", query)
+	}
+	q = renameVars(q[1:len(q)-1], prefix)
+
+	// Add prefix to the alias of each top-level field,
+	// adding an alias for fields that have none.
+	var buf strings.Builder
+	depth := 0
+	for i := 0; i < len(q); {
+		c := q[i]
+		switch {
+		case c == '"':
+			j := skipString(q, i)
+			buf.WriteString(q[i:j])
+			i = j
+			continue
+		case c == '(' || c == '{':
+			depth++
+		case c == ')' || c == '}':
+			depth--
+		case depth == 0 && c == '@':
+			// Directive: copy name unchanged.
+			j := i + 1
+			for j < len(q) && isNameChar(q[j]) {
+				j++
+			}
+			buf.WriteString(q[i:j])
+			i = j
+			continue
+		case depth == 0 && isNameChar(c):
+			j := i
+			for j < len(q) && isNameChar(q[j]) {
+				j++
+			}
+			name := q[i:j]
+			k := j
+			for k < len(q) && strings.IndexByte(" \t\r\n,", q[k]) >= 0 {
+				k++
+			}
+			if k < len(q) && q[k] == ':' {
+				// Existing alias; copy "alias: field" with prefix on alias.
+				k++
+				for k < len(q) && strings.IndexByte(" \t\r\n,", q[k]) >= 0 {
+					k++
+				}
+				for k < len(q) && isNameChar(q[k]) {
+					k++
+				}
+				buf.WriteString(prefix + name + q[j:k])
+				i = k
+			} else {
+				buf.WriteString(prefix + name + ": " + name)
+				i = j
+			}
+			fields = append(fields, name)
+			continue
+		}
+		buf.WriteByte(c)
+		i++
+	}
+	if depth != 0 {
+		return "", "", nil, fmt.Errorf("batch: malformed query: %s", query)
+	}
+	return decls, buf.String(), fields, nil
+}
+
+// renameVars adds prefix to every variable name in s.
+func renameVars(s, prefix string) string {
+	var buf strings.Builder
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '"':
+			j := skipString(s, i)
+			buf.WriteString(s[i:j])
+			i = j
+			continue
+		case '$':
+			buf.WriteString("$" + prefix)
+			i++
+			continue
+		}
+		buf.WriteByte(s[i])
+		i++
+	}
+	return buf.String()
+}
+
+// matching returns the index of the parenthesis matching the one at s[i],
+// or -1 if there is none.
+func matching(s string, i int) int {
+	depth := 0
+	for ; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			i = skipString(s, i) - 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// skipString returns the index just past the string literal starting at s[i].
+func skipString(s string, i int) int {
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '"':
+			return j + 1
+		}
+	}
+	return len(s)
+}
+
+func isNameChar(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_'
+}
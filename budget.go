@@ -0,0 +1,96 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// A Budget limits the GraphQL rate limit points spent by one or more Clients.
+// A single Budget can be shared by many clients (see [Client.WithBudget]),
+// for example to bound the total cost of a sweep over many organizations.
+//
+// GitHub reports the cost of each query, which a Budget adds to its total.
+// GitHub does not report the cost of mutations; a Budget counts each as one point.
+// To see the cost of individual requests, use [Client.WithOnResponse].
+type Budget struct {
+	// Limit is the maximum number of points to spend.
+	// Once the budget has spent Limit points, requests fail with a *BudgetError.
+	// Zero means no limit.
+	Limit int
+
+	// Reserve is the number of points to leave unspent in GitHub's hourly limit.
+	// If a Client's most recent request reported fewer than Reserve points
+	// remaining, the Client waits for the limit to reset before its next request
+	// (as its [RetryPolicy] allows),
+	// instead of running into the limit and being refused.
+	// Zero means no reserve.
+	Reserve int
+
+	mu   sync.Mutex
+	used int
+}
+
+// Used returns the number of points spent so far.
+func (b *Budget) Used() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.used
+}
+
+// A BudgetError reports that a request was not sent because it would exceed a [Budget].
+type BudgetError struct {
+	Limit int // the budget's limit
+	Used  int // points used so far
+}
+
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("github: budget exhausted: used %d of %d points", e.Used, e.Limit)
+}
+
+// WithBudget returns a shallow copy of c that charges its GraphQL requests to b.
+// Passing a nil b removes any budget.
+func (c *Client) WithBudget(b *Budget) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.budget = b
+	return c2
+}
+
+// checkBudget checks c's budget, if any, before a GraphQL request.
+// It returns a *BudgetError if the budget is spent,
+// and it waits for the rate limit to reset if the remaining points
+// have fallen below the budget's reserve.
+func (c *Client) checkBudget() error {
+	b := c.budget
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	used := b.used
+	b.mu.Unlock()
+	if b.Limit > 0 && used >= b.Limit {
+		return &BudgetError{Limit: b.Limit, Used: used}
+	}
+	if rl := c.RateLimit(); b.Reserve > 0 && rl != nil && rl.Remaining < b.Reserve {
+		if wait := time.Until(rl.ResetAt); wait > 0 {
+			err := fmt.Errorf("github: %d rate limit points remaining, below reserve of %d", rl.Remaining, b.Reserve)
+			// Wait an extra second to allow for clock skew.
+			return c.retryWait(1, err, wait+time.Second)
+		}
+	}
+	return nil
+}
+
+// spend charges cost points to c's budget, if any.
+func (c *Client) spend(cost int) {
+	if b := c.budget; b != nil {
+		b.mu.Lock()
+		b.used += cost
+		b.mu.Unlock()
+	}
+}
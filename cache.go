@@ -0,0 +1,131 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// A DiskCache is a cache of GraphQL query results stored in a directory on disk,
+// so that it can be shared by many runs of a program,
+// or by many programs.
+//
+// A Client using a DiskCache (see [Client.WithCache]) answers a query
+// from the cache if the same client identity (host and token) made
+// the same query with the same variables within the cache's TTL.
+// Only successful queries are cached; mutations are never cached.
+// A successful mutation made through a Client clears the Client's cache,
+// since the mutation may have changed the results of any cached query.
+type DiskCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// NewDiskCache returns a cache storing entries in dir,
+// which it creates if necessary.
+// Entries expire ttl after they are written.
+// A typical dir is a subdirectory of [os.UserCacheDir].
+func NewDiskCache(dir string, ttl time.Duration) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir, ttl: ttl}, nil
+}
+
+// Clear removes all entries from the cache.
+func (d *DiskCache) Clear() error {
+	files, err := filepath.Glob(filepath.Join(d.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, file := range files {
+		if err := os.Remove(file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// file returns the name of the file holding the entry for key.
+func (d *DiskCache) file(key string) string {
+	return filepath.Join(d.dir, key+".json")
+}
+
+// get returns the cached data for key, if present and unexpired.
+func (d *DiskCache) get(key string) ([]byte, bool) {
+	file := d.file(key)
+	info, err := os.Stat(file)
+	if err != nil || time.Since(info.ModTime()) > d.ttl {
+		return nil, false
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// put stores data as the cached data for key.
+// Errors are ignored: a cache write failure only means
+// that a later run must fetch the data again.
+func (d *DiskCache) put(key string, data []byte) {
+	f, err := os.CreateTemp(d.dir, "tmp-")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(data)
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), d.file(key))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+}
+
+// WithCache returns a shallow copy of c that uses the cache d for GraphQL queries.
+// Passing a nil d turns off caching.
+func (c *Client) WithCache(d *DiskCache) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.cache = d
+	return c2
+}
+
+// cacheKey returns the cache key for the GraphQL request body js.
+func (c *Client) cacheKey(js []byte) string {
+	token, _ := c.authToken()
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n", c.graphQLURL(), token)
+	h.Write(js)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cachedGraphQL decodes the cached reply for the GraphQL request body js
+// into reply, reporting whether it found one.
+func (c *Client) cachedGraphQL(js []byte, reply any) bool {
+	data, ok := c.cache.get(c.cacheKey(js))
+	if !ok {
+		return false
+	}
+	var jsreply struct {
+		Data json.RawMessage
+	}
+	if json.Unmarshal(data, &jsreply) != nil || json.Unmarshal(jsreply.Data, reply) != nil {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,221 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"fmt"
+	"time"
+
+	"rsc.io/github/schema"
+)
+
+const checkRunFields = `
+  id
+  name
+  status
+  conclusion
+  startedAt
+  completedAt
+  detailsUrl
+  url
+  title
+  summary
+`
+
+// A CheckSuite is a collection of check runs created by a single app for a commit.
+type CheckSuite struct {
+	ID         string
+	App        string
+	Status     schema.CheckStatusState
+	Conclusion schema.CheckConclusionState
+	Workflow   string // name of GitHub Actions workflow, if any
+	URL        string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Runs       []*CheckRun
+}
+
+// A CheckRun is a single check, such as a CI job, run against a commit.
+type CheckRun struct {
+	ID          string
+	Name        string
+	Status      schema.CheckStatusState
+	Conclusion  schema.CheckConclusionState
+	StartedAt   time.Time
+	CompletedAt time.Time
+	DetailsURL  string
+	URL         string
+	Title       string
+	Summary     string
+}
+
+func toCheckSuite(s *schema.CheckSuite) *CheckSuite {
+	cs := &CheckSuite{
+		ID:         string(s.Id),
+		Status:     s.Status,
+		Conclusion: s.Conclusion,
+		URL:        string(s.Url),
+		CreatedAt:  toTime(s.CreatedAt),
+		UpdatedAt:  toTime(s.UpdatedAt),
+	}
+	if s.App != nil {
+		cs.App = s.App.Name
+	}
+	if s.WorkflowRun != nil && s.WorkflowRun.Workflow != nil {
+		cs.Workflow = s.WorkflowRun.Workflow.Name
+	}
+	if s.CheckRuns != nil {
+		cs.Runs = apply(toCheckRun, s.CheckRuns.Nodes)
+	}
+	return cs
+}
+
+func toCheckRun(s *schema.CheckRun) *CheckRun {
+	return &CheckRun{
+		ID:          string(s.Id),
+		Name:        s.Name,
+		Status:      s.Status,
+		Conclusion:  s.Conclusion,
+		StartedAt:   toTime(s.StartedAt),
+		CompletedAt: toTime(s.CompletedAt),
+		DetailsURL:  string(s.DetailsUrl),
+		URL:         string(s.Url),
+		Title:       s.Title,
+		Summary:     s.Summary,
+	}
+}
+
+// CheckSuites returns the check suites, including their check runs,
+// for the commit identified by ref in org/repo.
+// The ref can be a branch name, tag name, or commit hash;
+// to check a pull request, use its HeadCommit.
+func (c *Client) CheckSuites(org, repo, ref string) ([]*CheckSuite, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Ref: String!, $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      object(expression: $Ref) {
+	        __typename
+	        ... on Commit {
+	          checkSuites(first: $PageSize, after: $Cursor) {
+	            pageInfo {
+	              hasNextPage
+	              endCursor
+	            }
+	            totalCount
+	            nodes {
+	              id
+	              app { name }
+	              status
+	              conclusion
+	              url
+	              createdAt
+	              updatedAt
+	              workflowRun { workflow { name } }
+	              checkRuns(first: 100) {
+	                nodes {
+	                  ` + checkRunFields + `
+	                }
+	              }
+	            }
+	          }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	var notCommit error
+	vars := Vars{"Org": org, "Repo": repo, "Ref": ref}
+	list, err := collect(c, graphql, vars, toCheckSuite,
+		func(q *schema.Query) pager[*schema.CheckSuite] {
+			commit, ok := q.Repository.Object.Interface.(*schema.Commit)
+			if !ok {
+				notCommit = fmt.Errorf("%s/%s: %s is not a commit", org, repo, ref)
+				return nil
+			}
+			return commit.CheckSuites
+		},
+	)
+	if err == nil {
+		err = notCommit
+	}
+	return list, err
+}
+
+// A CommitStatus is the combined status of a commit.
+type CommitStatus struct {
+	// State is the overall state of all check runs and statuses:
+	// SUCCESS, FAILURE, PENDING, ERROR, or EXPECTED.
+	// It is empty if the commit has no checks or statuses.
+	State schema.StatusState
+
+	// Statuses lists the commit statuses, which are the
+	// older, non-check-run form of status reporting.
+	Statuses []*StatusContext
+}
+
+// A StatusContext is a single commit status.
+type StatusContext struct {
+	Context     string
+	State       schema.StatusState
+	Description string
+	TargetURL   string
+	Creator     string
+	CreatedAt   time.Time
+}
+
+// CommitStatus returns the combined status of the commit identified by ref in org/repo.
+// The ref can be a branch name, tag name, or commit hash;
+// to check a pull request, use its HeadCommit.
+func (c *Client) CommitStatus(org, repo, ref string) (*CommitStatus, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Ref: String!) {
+	    repository(owner: $Org, name: $Repo) {
+	      object(expression: $Ref) {
+	        __typename
+	        ... on Commit {
+	          statusCheckRollup { state }
+	          status {
+	            contexts {
+	              context
+	              state
+	              description
+	              targetUrl
+	              creator { __typename login }
+	              createdAt
+	            }
+	          }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	q, err := c.GraphQLQuery(graphql, Vars{"Org": org, "Repo": repo, "Ref": ref})
+	if err != nil {
+		return nil, err
+	}
+	commit, ok := q.Repository.Object.Interface.(*schema.Commit)
+	if !ok {
+		return nil, fmt.Errorf("%s/%s: %s is not a commit", org, repo, ref)
+	}
+	st := new(CommitStatus)
+	if commit.StatusCheckRollup != nil {
+		st.State = commit.StatusCheckRollup.State
+	}
+	if commit.Status != nil {
+		for _, sc := range commit.Status.Contexts {
+			st.Statuses = append(st.Statuses, &StatusContext{
+				Context:     sc.Context,
+				State:       sc.State,
+				Description: sc.Description,
+				TargetURL:   string(sc.TargetUrl),
+				Creator:     toAuthor(&sc.Creator),
+				CreatedAt:   toTime(sc.CreatedAt),
+			})
+		}
+	}
+	return st, nil
+}
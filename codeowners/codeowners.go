@@ -0,0 +1,196 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package codeowners parses GitHub CODEOWNERS files
+// and answers the question “who owns this path?”
+//
+// The pattern syntax is that of GitHub's CODEOWNERS files,
+// which is a subset of .gitignore syntax:
+// later lines take precedence over earlier ones;
+// a pattern without a slash (other than a trailing one) matches at any depth;
+// a pattern with a leading or interior slash is relative to the repository root;
+// a trailing slash matches only directories, and everything within them;
+// * matches any sequence of non-slash characters, ? matches a single one,
+// and ** matches across directories.
+// As on GitHub, a pattern ending in /* matches files in that directory
+// but not in its subdirectories, and the !, [ ], and \# syntaxes
+// are not supported.
+package codeowners
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strings"
+
+	"rsc.io/github"
+)
+
+// A File is a parsed CODEOWNERS file.
+type File struct {
+	Path  string // path of file in repository, if loaded with Load
+	Rules []*Rule
+}
+
+// A Rule is a single line in a CODEOWNERS file.
+type Rule struct {
+	Line    int // line number in file
+	Pattern string
+	Owners  []string // users (@rsc), teams (@golang/release), or email addresses
+	re      *regexp.Regexp
+}
+
+// Parse parses the content of a CODEOWNERS file.
+// GitHub ignores lines it cannot parse, and so does Parse,
+// but it also returns an error listing those lines.
+// The returned File is non-nil even when the error is non-nil.
+func Parse(data []byte) (*File, error) {
+	f := new(File)
+	var errs []error
+	for i, line := range strings.Split(string(data), "\n") {
+		if j := strings.Index(line, "#"); j >= 0 {
+			line = line[:j]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		re, err := compile(fields[0])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %v", i+1, err))
+			continue
+		}
+		f.Rules = append(f.Rules, &Rule{
+			Line:    i + 1,
+			Pattern: fields[0],
+			Owners:  fields[1:],
+			re:      re,
+		})
+	}
+	return f, errors.Join(errs...)
+}
+
+// compile returns a regexp matching the paths matched by the CODEOWNERS pattern.
+func compile(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") {
+		return nil, fmt.Errorf("negated pattern %s not supported", pattern)
+	}
+	if strings.ContainsAny(pattern, "[]\\") {
+		return nil, fmt.Errorf("pattern %s uses unsupported [ ] or \\ syntax", pattern)
+	}
+
+	p := pattern
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	anchored := strings.HasPrefix(p, "/") || strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return nil, fmt.Errorf("empty pattern %s", pattern)
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(p); {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 3
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i += 2
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+			i++
+		case p[i] == '?':
+			b.WriteString("[^/]")
+			i++
+		default:
+			j := i + 1
+			for j < len(p) && p[j] != '*' && p[j] != '?' {
+				j++
+			}
+			b.WriteString(regexp.QuoteMeta(p[i:j]))
+			i = j
+		}
+	}
+	switch {
+	case dirOnly:
+		b.WriteString("/.*")
+	case strings.HasSuffix(p, "/*") && !strings.HasSuffix(p, "**/*"):
+		// GitHub: docs/* matches docs/x but not docs/x/y.
+	default:
+		// A pattern matching a directory matches everything in it.
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// Match reports whether the rule's pattern matches the file path,
+// which is relative to the repository root, as in "src/cmd/go/main.go".
+func (r *Rule) Match(path string) bool {
+	return r.re.MatchString(strings.TrimPrefix(path, "/"))
+}
+
+// Match returns the rule that determines the owners of the file path,
+// which is the last matching rule in the file,
+// or nil if no rule matches.
+func (f *File) Match(path string) *Rule {
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if r := f.Rules[i]; r.Match(path) {
+			return r
+		}
+	}
+	return nil
+}
+
+// Owners returns the owners of the file path.
+// It returns nil if no rule matches
+// or if the matching rule lists no owners.
+func (f *File) Owners(path string) []string {
+	if r := f.Match(path); r != nil {
+		return r.Owners
+	}
+	return nil
+}
+
+// Locations lists the locations GitHub checks for a CODEOWNERS file,
+// in the order it checks them.
+var Locations = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// Load loads and parses the CODEOWNERS file in org/repo at ref,
+// which can be a branch name, tag name, or commit hash.
+// If ref is the empty string, Load uses the repository's default branch.
+// If the repository has no CODEOWNERS file, Load returns an error
+// satisfying errors.Is(err, fs.ErrNotExist).
+// As with Parse, the returned File may be non-nil even when the error is non-nil.
+func Load(c *github.Client, org, repo, ref string) (*File, error) {
+	for _, path := range Locations {
+		blob, err := c.FileContent(org, repo, ref, path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if blob.Binary {
+			return nil, fmt.Errorf("%s/%s: %s is binary", org, repo, path)
+		}
+		f, err := Parse(blob.Data)
+		f.Path = path
+		if err != nil {
+			err = fmt.Errorf("%s/%s: %s: %w", org, repo, path, err)
+		}
+		return f, err
+	}
+	return nil, fmt.Errorf("%s/%s: no CODEOWNERS file: %w", org, repo, fs.ErrNotExist)
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package codeowners_test
+
+import (
+	"errors"
+	"io/fs"
+	"slices"
+	"testing"
+
+	"rsc.io/github/codeowners"
+	"rsc.io/github/githubtest"
+)
+
+func TestLoad(t *testing.T) {
+	c := githubtest.Client(t, "testdata/load.json")
+
+	// Each repository has its CODEOWNERS file in a different location,
+	// so Load must fall back past the missing ones.
+	for _, tt := range []struct {
+		repo, path string
+	}{
+		{"github-dir", ".github/CODEOWNERS"},
+		{"root", "CODEOWNERS"},
+		{"docs", "docs/CODEOWNERS"},
+	} {
+		f, err := codeowners.Load(c, "rsc", tt.repo, "")
+		if err != nil {
+			t.Errorf("Load(rsc/%s): %v", tt.repo, err)
+			continue
+		}
+		if f.Path != tt.path {
+			t.Errorf("Load(rsc/%s).Path = %q, want %q", tt.repo, f.Path, tt.path)
+		}
+	}
+
+	_, err := codeowners.Load(c, "rsc", "none", "")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Load(rsc/none): err = %v, want fs.ErrNotExist", err)
+	}
+}
+
+var ownersTests = []struct {
+	path   string
+	owners []string
+}{
+	{"README", []string{"@everyone"}},
+	{"main.go", []string{"@gopher"}},
+	{"cmd/go/main.go", []string{"@gopher"}},
+	{"docs/index.md", []string{"@docs"}},
+	{"docs/sub/index.md", []string{"@docs"}},
+	{"src/docs/index.md", []string{"@everyone"}},
+	{"build/x", []string{"@build"}},
+	{"build/x/y", []string{"@build"}},
+	{"sub/build/x", []string{"@build"}},
+	{"api/v1", []string{"@api"}},
+	{"api/v1/x", []string{"@everyone"}},
+	{"lib/a/b/c.txt", []string{"@txt"}},
+	{"lib/c.txt", []string{"@txt"}},
+	{"lib/c.TXT", []string{"@everyone"}},
+	{"vendor/x.go", nil},
+}
+
+const ownersFile = `
+# Default owners.
+*              @everyone
+*.go           @gopher
+/docs/         @docs
+build/         @build
+/api/*         @api
+lib/**/*.txt   @txt
+/vendor/       # no owners
+`
+
+func TestOwners(t *testing.T) {
+	f, err := codeowners.Parse([]byte(ownersFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range ownersTests {
+		if owners := f.Owners(tt.path); !slices.Equal(owners, tt.owners) {
+			t.Errorf("Owners(%q) = %v, want %v", tt.path, owners, tt.owners)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	f, err := codeowners.Parse([]byte("*.go @gopher\n!x @rsc\n[ab] @rsc\n"))
+	if err == nil {
+		t.Fatal("Parse succeeded, want errors for lines 2 and 3")
+	}
+	if len(f.Rules) != 1 || f.Rules[0].Pattern != "*.go" {
+		t.Errorf("Parse kept %d rules, want only *.go", len(f.Rules))
+	}
+}
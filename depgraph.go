@@ -0,0 +1,93 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SBOM returns the software bill of materials for org/repo,
+// derived from its dependency graph, as an SPDX JSON document.
+func (c *Client) SBOM(org, repo string) ([]byte, error) {
+	var reply struct {
+		SBOM json.RawMessage `json:"sbom"`
+	}
+	if err := c.rest("GET", "/repos/"+org+"/"+repo+"/dependency-graph/sbom", nil, &reply); err != nil {
+		return nil, err
+	}
+	if len(reply.SBOM) == 0 {
+		return nil, fmt.Errorf("%s/%s: no SBOM in reply", org, repo)
+	}
+	return reply.SBOM, nil
+}
+
+// A Dependency is a package in a repository's dependency graph.
+type Dependency struct {
+	Ecosystem string // package URL type, like "golang", "npm", or "pypi"
+	Name      string // like "golang.org/x/net"
+	Version   string // like "v0.24.0"; empty if unknown
+	PURL      string // package URL, like "pkg:golang/golang.org/x/net@v0.24.0"
+	License   string // SPDX license expression, if known
+}
+
+// Dependencies returns the packages in the dependency graph of org/repo,
+// as listed in its SBOM.
+// The list includes both direct and indirect dependencies.
+func (c *Client) Dependencies(org, repo string) ([]*Dependency, error) {
+	data, err := c.SBOM(org, repo)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		DocumentDescribes []string `json:"documentDescribes"`
+		Packages          []struct {
+			SPDXID           string `json:"SPDXID"`
+			Name             string `json:"name"`
+			VersionInfo      string `json:"versionInfo"`
+			LicenseConcluded string `json:"licenseConcluded"`
+			ExternalRefs     []struct {
+				ReferenceType    string `json:"referenceType"`
+				ReferenceLocator string `json:"referenceLocator"`
+			} `json:"externalRefs"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s/%s: parsing SBOM: %v", org, repo, err)
+	}
+
+	self := make(map[string]bool)
+	for _, id := range doc.DocumentDescribes {
+		self[id] = true
+	}
+	var deps []*Dependency
+	for _, p := range doc.Packages {
+		if self[p.SPDXID] {
+			continue
+		}
+		d := &Dependency{
+			Name:    p.Name,
+			Version: p.VersionInfo,
+		}
+		if p.LicenseConcluded != "NOASSERTION" {
+			d.License = p.LicenseConcluded
+		}
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				d.PURL = ref.ReferenceLocator
+				typ, _, _ := strings.Cut(strings.TrimPrefix(d.PURL, "pkg:"), "/")
+				d.Ecosystem = typ
+				break
+			}
+		}
+		// SPDX package names are prefixed by ecosystem, as in "go:golang.org/x/net".
+		if _, name, ok := strings.Cut(d.Name, ":"); ok && d.Ecosystem != "" {
+			d.Name = name
+		}
+		deps = append(deps, d)
+	}
+	return deps, nil
+}
@@ -0,0 +1,248 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"time"
+
+	"rsc.io/github/schema"
+)
+
+const deploymentStatusFields = `
+  id
+  state
+  description
+  environmentUrl
+  logUrl
+  creator { __typename login }
+  createdAt
+  updatedAt
+`
+
+const deploymentFields = `
+  id
+  databaseId
+  environment
+  originalEnvironment
+  state
+  commitOid
+  ref { name }
+  task
+  description
+  creator { __typename login }
+  createdAt
+  updatedAt
+  repository { name owner { __typename login } }
+  latestStatus {
+    ` + deploymentStatusFields + `
+  }
+`
+
+// A Deployment is a request to deploy a specific commit to an environment.
+type Deployment struct {
+	ID                  string
+	DatabaseID          int
+	Owner               string
+	Repo                string
+	Environment         string // latest environment
+	OriginalEnvironment string
+	State               schema.DeploymentState
+	Commit              string
+	Ref                 string // empty if deployment was not created by ref
+	Task                string
+	Description         string
+	Creator             string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	LatestStatus        *DeploymentStatus // nil if no statuses
+}
+
+// A DeploymentStatus is a single status update for a deployment.
+type DeploymentStatus struct {
+	ID             string
+	State          schema.DeploymentStatusState
+	Description    string
+	EnvironmentURL string
+	LogURL         string
+	Creator        string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func toDeployment(s *schema.Deployment) *Deployment {
+	d := &Deployment{
+		ID:                  string(s.Id),
+		DatabaseID:          s.DatabaseId,
+		Environment:         s.Environment,
+		OriginalEnvironment: s.OriginalEnvironment,
+		State:               s.State,
+		Commit:              s.CommitOid,
+		Task:                s.Task,
+		Description:         s.Description,
+		Creator:             toAuthor(&s.Creator),
+		CreatedAt:           toTime(s.CreatedAt),
+		UpdatedAt:           toTime(s.UpdatedAt),
+	}
+	if s.Repository != nil {
+		d.Owner = toOwner(&s.Repository.Owner)
+		d.Repo = s.Repository.Name
+	}
+	if s.Ref != nil {
+		d.Ref = s.Ref.Name
+	}
+	if s.LatestStatus != nil {
+		d.LatestStatus = toDeploymentStatus(s.LatestStatus)
+	}
+	return d
+}
+
+func toDeploymentStatus(s *schema.DeploymentStatus) *DeploymentStatus {
+	return &DeploymentStatus{
+		ID:             string(s.Id),
+		State:          s.State,
+		Description:    s.Description,
+		EnvironmentURL: string(s.EnvironmentUrl),
+		LogURL:         string(s.LogUrl),
+		Creator:        toAuthor(&s.Creator),
+		CreatedAt:      toTime(s.CreatedAt),
+		UpdatedAt:      toTime(s.UpdatedAt),
+	}
+}
+
+// Deployments returns the deployments in org/repo, most recent first.
+// If environments are listed, only deployments to those environments are returned.
+func (c *Client) Deployments(org, repo string, environments ...string) ([]*Deployment, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Environments: [String!], $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      deployments(first: $PageSize, after: $Cursor, environments: $Environments, orderBy: {field: CREATED_AT, direction: DESC}) {
+	        pageInfo {
+	          hasNextPage
+	          endCursor
+	        }
+	        totalCount
+	        nodes {
+	          ` + deploymentFields + `
+	        }
+	      }
+	    }
+	  }
+	`
+
+	vars := Vars{"Org": org, "Repo": repo}
+	if len(environments) > 0 {
+		vars["Environments"] = environments
+	}
+	return collect(c, graphql, vars, toDeployment,
+		func(q *schema.Query) pager[*schema.Deployment] {
+			if q.Repository == nil || q.Repository.Deployments == nil {
+				return nil
+			}
+			return q.Repository.Deployments
+		},
+	)
+}
+
+// DeploymentStatuses returns the statuses of the deployment, oldest first.
+func (c *Client) DeploymentStatuses(d *Deployment) ([]*DeploymentStatus, error) {
+	graphql := `
+	  query($ID: ID!, $Cursor: String, $PageSize: Int = 100) {
+	    node(id: $ID) {
+	      __typename
+	      ... on Deployment {
+	        statuses(first: $PageSize, after: $Cursor) {
+	          pageInfo {
+	            hasNextPage
+	            endCursor
+	          }
+	          totalCount
+	          nodes {
+	            ` + deploymentStatusFields + `
+	          }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	return collect(c, graphql, Vars{"ID": d.ID}, toDeploymentStatus,
+		func(q *schema.Query) pager[*schema.DeploymentStatus] {
+			d, ok := q.Node.Interface.(*schema.Deployment)
+			if !ok || d.Statuses == nil {
+				return nil
+			}
+			return d.Statuses
+		},
+	)
+}
+
+// An Environment is a deployment target, such as "production" or "staging".
+type Environment struct {
+	ID              string
+	DatabaseID      int
+	Owner           string
+	Repo            string
+	Name            string
+	ProtectionRules []*EnvironmentProtectionRule
+}
+
+// An EnvironmentProtectionRule is a rule that must be satisfied
+// before deploying to an environment.
+type EnvironmentProtectionRule struct {
+	Type    schema.DeploymentProtectionRuleType
+	Timeout int // for WAIT_TIMER, in minutes
+}
+
+// Environments returns the deployment environments in org/repo.
+func (c *Client) Environments(org, repo string) ([]*Environment, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      environments(first: $PageSize, after: $Cursor) {
+	        pageInfo {
+	          hasNextPage
+	          endCursor
+	        }
+	        totalCount
+	        nodes {
+	          id
+	          databaseId
+	          name
+	          protectionRules(first: 100) {
+	            nodes {
+	              type
+	              timeout
+	            }
+	          }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	toEnvironment := func(s *schema.Environment) *Environment {
+		env := &Environment{
+			ID:         string(s.Id),
+			DatabaseID: s.DatabaseId,
+			Owner:      org,
+			Repo:       repo,
+			Name:       s.Name,
+		}
+		if s.ProtectionRules != nil {
+			for _, r := range s.ProtectionRules.Nodes {
+				env.ProtectionRules = append(env.ProtectionRules, &EnvironmentProtectionRule{Type: r.Type, Timeout: r.Timeout})
+			}
+		}
+		return env
+	}
+	return collect(c, graphql, Vars{"Org": org, "Repo": repo}, toEnvironment,
+		func(q *schema.Query) pager[*schema.Environment] {
+			if q.Repository == nil || q.Repository.Environments == nil {
+				return nil
+			}
+			return q.Repository.Environments
+		},
+	)
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// A DryRun records the mutations made by a Client in dry-run mode
+// (see [Client.WithDryRun]) instead of sending them to GitHub.
+type DryRun struct {
+	mu   sync.Mutex
+	list []*RecordedMutation
+}
+
+// A RecordedMutation is a mutation recorded by a [DryRun].
+type RecordedMutation struct {
+	Name  string // GraphQL mutation, as in "addComment", or REST method and path, as in "PATCH /gists/123"
+	Query string // GraphQL mutation text; empty for REST requests
+	Vars  Vars   // GraphQL variables; nil for REST requests
+	Body  any    // REST request body; nil for GraphQL requests
+}
+
+func (m *RecordedMutation) String() string {
+	if m.Query == "" {
+		return m.Name
+	}
+	return summarize(m.Name, m.Vars)
+}
+
+// Mutations returns the mutations recorded so far, in order.
+func (d *DryRun) Mutations() []*RecordedMutation {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]*RecordedMutation(nil), d.list...)
+}
+
+func (d *DryRun) record(m *RecordedMutation) {
+	d.mu.Lock()
+	d.list = append(d.list, m)
+	d.mu.Unlock()
+}
+
+// ErrDryRun is returned by methods such as [Client.CreateIssue]
+// that must return an object created or changed by a mutation,
+// when the mutation was only recorded by a dry run.
+var ErrDryRun = errors.New("github: mutation not executed in dry run")
+
+// WithDryRun returns a shallow copy of c that records mutations in d
+// instead of executing them. Queries are still executed.
+// Recorded mutations appear to succeed, so that a program can run
+// its usual logic and report what it would have changed.
+// Mutations include GraphQL mutations and REST requests
+// other than GET and HEAD.
+// Passing a nil d turns off dry-run mode.
+func (c *Client) WithDryRun(d *DryRun) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.dryRun = d
+	return c2
+}
+
+// noResult returns the error for a mutation op whose reply is missing its result,
+// as happens for mutations recorded in dry-run mode.
+func (c *Client) noResult(op string) error {
+	if c.dryRun != nil {
+		return ErrDryRun
+	}
+	return fmt.Errorf("%s: missing result", op)
+}
+
+// isDryRunREST reports whether a REST request using method
+// is recorded instead of sent, because c is in dry-run mode.
+// The synthetic response to a recorded request has no body,
+// so callers that decode the response must check isDryRunREST first.
+func (c *Client) isDryRunREST(method string) bool {
+	return c.dryRun != nil && method != "GET" && method != "HEAD"
+}
+
+// dryRunREST records a REST request as a mutation if c is in dry-run mode,
+// returning a synthetic successful response.
+// It returns nil if the request should be sent.
+func (c *Client) dryRunREST(method, path string, body any) *http.Response {
+	if !c.isDryRunREST(method) {
+		return nil
+	}
+	c.dryRun.record(&RecordedMutation{Name: method + " " + path, Body: body})
+	return &http.Response{Status: "200 OK", StatusCode: http.StatusOK, Header: make(http.Header)}
+}
+
+// mutationName returns the name of the first top-level field in the GraphQL mutation,
+// as in "addComment".
+func mutationName(query string) string {
+	depth := 0
+	for i := 0; i < len(query); i++ {
+		switch query[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '{':
+			if depth == 0 {
+				s := strings.TrimSpace(query[i+1:])
+				j := 0
+				for j < len(s) && isNameChar(s[j]) {
+					j++
+				}
+				return s[:j]
+			}
+		}
+	}
+	return ""
+}
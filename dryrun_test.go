@@ -0,0 +1,93 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github_test
+
+import (
+	"errors"
+	"testing"
+
+	"rsc.io/github"
+	"rsc.io/github/githubtest"
+)
+
+// dryRunClient returns a client in dry-run mode.
+// Its recording is empty, so any request it sends fails the test.
+func dryRunClient(t *testing.T) (*github.Client, *github.DryRun) {
+	d := new(github.DryRun)
+	return githubtest.Client(t, "testdata/dryrun.json").WithDryRun(d), d
+}
+
+func TestDryRunRuleset(t *testing.T) {
+	c, d := dryRunClient(t)
+	r, err := c.UpdateRuleset(&github.Ruleset{ID: "RRS_1", Name: "main"})
+	if r != nil || !errors.Is(err, github.ErrDryRun) {
+		t.Fatalf("UpdateRuleset = %v, %v, want nil, ErrDryRun", r, err)
+	}
+	if m := d.Mutations(); len(m) != 1 || m[0].Name != "updateRepositoryRuleset" {
+		t.Errorf("recorded %v, want updateRepositoryRuleset", m)
+	}
+}
+
+func TestDryRunProjectField(t *testing.T) {
+	c, d := dryRunClient(t)
+	p := &github.Project{ID: "PVT_1"}
+	f, err := c.CreateProjectField(p, "Priority", "NUMBER")
+	if f != nil || !errors.Is(err, github.ErrDryRun) {
+		t.Fatalf("CreateProjectField = %v, %v, want nil, ErrDryRun", f, err)
+	}
+	if len(p.Fields) != 0 {
+		t.Errorf("after dry-run CreateProjectField, project has fields %v", p.Fields)
+	}
+	if m := d.Mutations(); len(m) != 1 || m[0].Name != "createProjectV2Field" {
+		t.Errorf("recorded %v, want createProjectV2Field", m)
+	}
+}
+
+func TestDryRunREST(t *testing.T) {
+	c, d := dryRunClient(t)
+
+	if g, err := c.CreateGist("test", false, map[string]string{"a.txt": "hello"}); g != nil || !errors.Is(err, github.ErrDryRun) {
+		t.Errorf("CreateGist = %v, %v, want nil, ErrDryRun", g, err)
+	}
+	if g, err := c.UpdateGist(&github.Gist{ID: "123"}, "test", nil); g != nil || !errors.Is(err, github.ErrDryRun) {
+		t.Errorf("UpdateGist = %v, %v, want nil, ErrDryRun", g, err)
+	}
+	if r, err := c.CreateFork(&github.Repo{Owner: "rsc", Repo: "quote"}, ""); r != nil || !errors.Is(err, github.ErrDryRun) {
+		t.Errorf("CreateFork = %v, %v, want nil, ErrDryRun", r, err)
+	}
+
+	// Dry-run changes to alerts must leave the alerts as they were.
+	code := &github.CodeScanningAlert{Number: 1, Owner: "rsc", Repo: "quote", State: "open", Rule: "go/sql-injection"}
+	if err := c.DismissCodeScanningAlert(code, "false positive", ""); err != nil {
+		t.Errorf("DismissCodeScanningAlert: %v", err)
+	}
+	if code.State != "open" || code.Number != 1 || code.Rule != "go/sql-injection" {
+		t.Errorf("after dry-run DismissCodeScanningAlert, alert = %+v", code)
+	}
+	secret := &github.SecretScanningAlert{Number: 2, Owner: "rsc", Repo: "quote", State: "open", SecretType: "github_personal_access_token"}
+	if err := c.ReopenSecretScanningAlert(secret); err != nil {
+		t.Errorf("ReopenSecretScanningAlert: %v", err)
+	}
+	if secret.State != "open" || secret.Number != 2 || secret.SecretType != "github_personal_access_token" {
+		t.Errorf("after dry-run ReopenSecretScanningAlert, alert = %+v", secret)
+	}
+
+	want := []string{
+		"POST /gists",
+		"PATCH /gists/123",
+		"POST /repos/rsc/quote/forks",
+		"PATCH /repos/rsc/quote/code-scanning/alerts/1",
+		"PATCH /repos/rsc/quote/secret-scanning/alerts/2",
+	}
+	m := d.Mutations()
+	if len(m) != len(want) {
+		t.Fatalf("recorded %d mutations, want %d: %v", len(m), len(want), m)
+	}
+	for i, w := range want {
+		if m[i].Name != w {
+			t.Errorf("mutation %d = %s, want %s", i, m[i].Name, w)
+		}
+	}
+}
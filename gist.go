@@ -0,0 +1,153 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"sort"
+	"time"
+)
+
+// A Gist is a GitHub gist: a small collection of files with its own URL.
+type Gist struct {
+	ID          string
+	Owner       string
+	Description string
+	Public      bool
+	URL         string
+	Files       []*GistFile // sorted by name
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// A GistFile is a single file in a gist.
+type GistFile struct {
+	Name      string
+	Language  string
+	Size      int
+	Content   string // omitted from lists of gists
+	Truncated bool   // Content is incomplete; fetch RawURL for the full file
+	RawURL    string
+}
+
+type gistJSON struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Public      bool   `json:"public"`
+	HTMLURL     string `json:"html_url"`
+	Owner       struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	Files map[string]struct {
+		Filename  string `json:"filename"`
+		Language  string `json:"language"`
+		Size      int    `json:"size"`
+		Content   string `json:"content"`
+		Truncated bool   `json:"truncated"`
+		RawURL    string `json:"raw_url"`
+	} `json:"files"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func toGist(j *gistJSON) *Gist {
+	g := &Gist{
+		ID:          j.ID,
+		Owner:       j.Owner.Login,
+		Description: j.Description,
+		Public:      j.Public,
+		URL:         j.HTMLURL,
+		CreatedAt:   j.CreatedAt,
+		UpdatedAt:   j.UpdatedAt,
+	}
+	for name, f := range j.Files {
+		g.Files = append(g.Files, &GistFile{
+			Name:      name,
+			Language:  f.Language,
+			Size:      f.Size,
+			Content:   f.Content,
+			Truncated: f.Truncated,
+			RawURL:    f.RawURL,
+		})
+	}
+	sort.Slice(g.Files, func(i, k int) bool { return g.Files[i].Name < g.Files[k].Name })
+	return g
+}
+
+// File returns the file in the gist with the given name, or nil if there is none.
+func (g *Gist) File(name string) *GistFile {
+	for _, f := range g.Files {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// Gist returns the gist with the given ID.
+func (c *Client) Gist(id string) (*Gist, error) {
+	var j gistJSON
+	if err := c.rest("GET", "/gists/"+id, nil, &j); err != nil {
+		return nil, err
+	}
+	return toGist(&j), nil
+}
+
+// UserGists returns the gists owned by user that are visible to the authenticated user.
+// The file contents are not included; use Gist to fetch them.
+func (c *Client) UserGists(user string) ([]*Gist, error) {
+	list, err := restCollect[*gistJSON](c, "/users/"+user+"/gists", "")
+	return apply(toGist, list), err
+}
+
+// CreateGist creates a new gist containing files, which maps file names to content.
+// A secret gist (public == false) is unlisted but visible to anyone with its URL.
+func (c *Client) CreateGist(description string, public bool, files map[string]string) (*Gist, error) {
+	body := map[string]any{
+		"description": description,
+		"public":      public,
+		"files":       gistFiles(files),
+	}
+	var j gistJSON
+	if err := c.rest("POST", "/gists", body, &j); err != nil {
+		return nil, err
+	}
+	if c.isDryRunREST("POST") {
+		return nil, ErrDryRun
+	}
+	return toGist(&j), nil
+}
+
+// UpdateGist updates the gist's description and files,
+// returning the new state of the gist.
+// Files not mentioned in files are left unchanged,
+// and files mapped to the empty string are deleted.
+func (c *Client) UpdateGist(g *Gist, description string, files map[string]string) (*Gist, error) {
+	body := map[string]any{
+		"description": description,
+		"files":       gistFiles(files),
+	}
+	var j gistJSON
+	if err := c.rest("PATCH", "/gists/"+g.ID, body, &j); err != nil {
+		return nil, err
+	}
+	if c.isDryRunREST("PATCH") {
+		return nil, ErrDryRun
+	}
+	return toGist(&j), nil
+}
+
+// gistFiles converts a map from file name to content
+// into the form used in gist API requests.
+func gistFiles(files map[string]string) map[string]any {
+	m := make(map[string]any)
+	for name, content := range files {
+		if content == "" {
+			m[name] = nil
+		} else {
+			m[name] = map[string]string{"content": content}
+		}
+	}
+	return m
+}
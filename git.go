@@ -0,0 +1,363 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"rsc.io/github/schema"
+)
+
+// A Ref is a Git reference, such as a branch or tag.
+type Ref struct {
+	Name   string // full name, like "refs/heads/master"
+	ID     string
+	Commit string // hash of commit the ref points at, after following tags
+	Tag    string // hash of annotated tag object, if any
+}
+
+func toRef(s *schema.Ref) *Ref {
+	r := &Ref{
+		Name: s.Prefix + s.Name,
+		ID:   string(s.Id),
+	}
+	switch t := s.Target.Interface.(type) {
+	case *schema.Commit:
+		r.Commit = string(t.Oid)
+	case *schema.Tag:
+		r.Tag = string(t.Oid)
+		if t.Target.Interface != nil {
+			r.Commit = string(t.Target.Interface.GetOid())
+		}
+	}
+	return r
+}
+
+// Refs returns the Git references in org/repo with names beginning with prefix,
+// such as "refs/heads/" or "refs/tags/". The prefix is always treated as
+// a directory name: "refs/tags" is the same as "refs/tags/".
+// If prefix is the empty string, Refs returns all branches and tags.
+func (c *Client) Refs(org, repo, prefix string) ([]*Ref, error) {
+	if prefix == "" {
+		heads, err := c.Refs(org, repo, "refs/heads/")
+		if err != nil {
+			return heads, err
+		}
+		tags, err := c.Refs(org, repo, "refs/tags/")
+		return append(heads, tags...), err
+	}
+
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Prefix: String!, $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      refs(first: $PageSize, refPrefix: $Prefix, after: $Cursor) {
+	        pageInfo {
+	          hasNextPage
+	          endCursor
+	        }
+	        totalCount
+	        nodes {
+	          id
+	          name
+	          prefix
+	          target {
+	            __typename
+	            oid
+	            ... on Tag {
+	              target {
+	                __typename
+	                oid
+	              }
+	            }
+	          }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	vars := Vars{"Org": org, "Repo": repo, "Prefix": prefix}
+	return collect(c, graphql, vars, toRef,
+		func(q *schema.Query) pager[*schema.Ref] { return q.Repository.Refs },
+	)
+}
+
+const commitFields = `
+  oid
+  url
+  message
+  author { name email date user { login } }
+  committer { name email date user { login } }
+  associatedPullRequests(first: 5) {
+    nodes {
+      ` + pullRequestFields + `
+    }
+  }
+`
+
+// A Commit is a Git commit.
+type Commit struct {
+	Hash         string
+	URL          string
+	Message      string
+	Author       *GitActor
+	Committer    *GitActor
+	PullRequests []*PullRequest // pull requests associated with the commit
+}
+
+// A GitActor is the author or committer of a commit.
+type GitActor struct {
+	Name  string
+	Email string
+	Login string // GitHub login, if known
+	Date  time.Time
+}
+
+func toCommit(s *schema.Commit) *Commit {
+	c := &Commit{
+		Hash:      string(s.Oid),
+		URL:       string(s.Url),
+		Message:   s.Message,
+		Author:    toGitActor(s.Author),
+		Committer: toGitActor(s.Committer),
+	}
+	if s.AssociatedPullRequests != nil {
+		c.PullRequests = apply(toPullRequest, s.AssociatedPullRequests.Nodes)
+	}
+	return c
+}
+
+// moreCommitLabels fetches the remaining labels for the pull requests
+// associated with the commits in list.
+func (c *Client) moreCommitLabels(list []*Commit) error {
+	var prs []*PullRequest
+	for _, commit := range list {
+		prs = append(prs, commit.PullRequests...)
+	}
+	return c.morePullRequestLabels(prs)
+}
+
+func toGitActor(s *schema.GitActor) *GitActor {
+	if s == nil {
+		return nil
+	}
+	a := &GitActor{
+		Name:  s.Name,
+		Email: s.Email,
+		Date:  toGitTime(s.Date),
+	}
+	if s.User != nil {
+		a.Login = s.User.Login
+	}
+	return a
+}
+
+func toGitTime(s schema.GitTimestamp) time.Time {
+	return toTime(schema.DateTime(s))
+}
+
+// Commits returns the history of commits reachable from ref in org/repo,
+// most recent first.
+// The ref can be a branch name, tag name, or commit hash;
+// if ref is the empty string, Commits uses the repository's default branch.
+// If since or until is non-zero, Commits returns only commits
+// made at or after since and before until.
+func (c *Client) Commits(org, repo, ref string, since, until time.Time) ([]*Commit, error) {
+	if ref == "" {
+		r, err := c.Repo(org, repo)
+		if err != nil {
+			return nil, err
+		}
+		ref = r.DefaultBranch
+	}
+
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Ref: String!, $Since: GitTimestamp, $Until: GitTimestamp, $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      object(expression: $Ref) {
+	        __typename
+	        ... on Commit {
+	          history(first: $PageSize, since: $Since, until: $Until, after: $Cursor) {
+	            pageInfo {
+	              hasNextPage
+	              endCursor
+	            }
+	            totalCount
+	            nodes {
+	              ` + commitFields + `
+	            }
+	          }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	vars := Vars{"Org": org, "Repo": repo, "Ref": ref}
+	if !since.IsZero() {
+		vars["Since"] = since.UTC().Format(time.RFC3339)
+	}
+	if !until.IsZero() {
+		vars["Until"] = until.UTC().Format(time.RFC3339)
+	}
+	var notCommit error
+	list, err := collect(c, graphql, vars, toCommit,
+		func(q *schema.Query) pager[*schema.Commit] {
+			commit, ok := q.Repository.Object.Interface.(*schema.Commit)
+			if !ok {
+				notCommit = fmt.Errorf("%s/%s: %s is not a commit", org, repo, ref)
+				return nil
+			}
+			return commit.History
+		},
+	)
+	if err == nil {
+		err = notCommit
+	}
+	if err == nil {
+		err = c.moreCommitLabels(list)
+	}
+	return list, err
+}
+
+// A Comparison is the result of comparing two refs.
+type Comparison struct {
+	AheadBy  int       // number of commits in head but not base
+	BehindBy int       // number of commits in base but not head
+	Status   string    // "AHEAD", "BEHIND", "DIVERGED", or "IDENTICAL"
+	Commits  []*Commit // commits in head but not base, oldest first
+}
+
+// Compare compares the refs base and head in org/repo.
+// The base must be a branch or tag name; head can also be a commit hash.
+func (c *Client) Compare(org, repo, base, head string) (*Comparison, error) {
+	// Ref.compare is newer than the schema package.
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Base: String!, $Head: String!, $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      ref(qualifiedName: $Base) {
+	        compare(headRef: $Head) {
+	          aheadBy
+	          behindBy
+	          status
+	          commits(first: $PageSize, after: $Cursor) {
+	            pageInfo {
+	              hasNextPage
+	              endCursor
+	            }
+	            totalCount
+	            nodes {
+	              ` + commitFields + `
+	            }
+	          }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	type reply struct {
+		Repository struct {
+			Ref *struct {
+				Compare *struct {
+					AheadBy  int
+					BehindBy int
+					Status   string
+					Commits  *connection[*schema.Commit]
+				}
+			}
+		}
+	}
+
+	cmp := new(Comparison)
+	var missing error
+	vars := Vars{"Org": org, "Repo": repo, "Base": base, "Head": head}
+	list, err := collectReply(c, graphql, vars, toCommit,
+		func(q *reply) pager[*schema.Commit] {
+			if q.Repository.Ref == nil || q.Repository.Ref.Compare == nil || q.Repository.Ref.Compare.Commits == nil {
+				missing = fmt.Errorf("%s/%s: cannot compare %s...%s", org, repo, base, head)
+				return nil
+			}
+			x := q.Repository.Ref.Compare
+			cmp.AheadBy = x.AheadBy
+			cmp.BehindBy = x.BehindBy
+			cmp.Status = x.Status
+			return x.Commits
+		},
+	)
+	if err == nil {
+		err = missing
+	}
+	if err == nil {
+		err = c.moreCommitLabels(list)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cmp.Commits = list
+	return cmp, nil
+}
+
+// A Blob is the content of a file stored in Git.
+type Blob struct {
+	Hash      string
+	Size      int    // size of file in bytes
+	Data      []byte // file content; nil if Binary is true
+	Binary    bool   // content is binary and was not returned
+	Truncated bool   // content was too large and was truncated
+}
+
+// FileContent returns the content of the file with the given path
+// in org/repo at ref, which can be a branch name, tag name, or commit hash.
+// If ref is the empty string, FileContent uses the repository's default branch.
+// If the file does not exist, the error satisfies errors.Is(err, fs.ErrNotExist).
+func (c *Client) FileContent(org, repo, ref, path string) (*Blob, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Expr: String!) {
+	    repository(owner: $Org, name: $Repo) {
+	      object(expression: $Expr) {
+	        __typename
+	        ... on Blob {
+	          oid
+	          byteSize
+	          isBinary
+	          isTruncated
+	          text
+	        }
+	      }
+	    }
+	  }
+	`
+	vars := Vars{"Org": org, "Repo": repo, "Expr": ref + ":" + strings.TrimPrefix(path, "/")}
+	q, err := c.GraphQLQuery(graphql, vars)
+	if err != nil {
+		return nil, err
+	}
+	switch obj := q.Repository.Object.Interface.(type) {
+	case nil:
+		return nil, fmt.Errorf("%s/%s: %s:%s: %w", org, repo, ref, path, fs.ErrNotExist)
+	case *schema.Blob:
+		b := &Blob{
+			Hash:      string(obj.Oid),
+			Size:      obj.ByteSize,
+			Binary:    obj.IsBinary,
+			Truncated: obj.IsTruncated,
+		}
+		if !obj.IsBinary {
+			b.Data = []byte(obj.Text)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("%s/%s: %s:%s is not a file", org, repo, ref, path)
+}
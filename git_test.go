@@ -0,0 +1,30 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"rsc.io/github/githubtest"
+)
+
+func TestFileContent(t *testing.T) {
+	c := githubtest.Client(t, "testdata/filecontent.json")
+
+	b, err := c.FileContent("rsc", "quote", "", "README.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b.Data) != "Hello, world" || b.Hash != "a1b2c3" || b.Size != 12 {
+		t.Errorf("FileContent(README.md) = %+v, want Hello, world", b)
+	}
+
+	_, err = c.FileContent("rsc", "quote", "", "missing.txt")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("FileContent(missing.txt): err = %v, want fs.ErrNotExist", err)
+	}
+}
@@ -7,3 +7,5 @@
 // The entire GitHub API can be accessed by using the [Client] with GraphQL schema from
 // [rsc.io/github/schema].
 package github
+
+//go:generate go run mkiface.go
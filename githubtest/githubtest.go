@@ -0,0 +1,230 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package githubtest supports testing code that uses a [github.Client]
+// by recording GitHub API traffic to a file and replaying it later.
+//
+// A typical test looks like:
+//
+//	func TestMinutes(t *testing.T) {
+//		c := githubtest.Client(t, "testdata/minutes.json")
+//		... use c ...
+//	}
+//
+// Normally, the client replays responses from the file,
+// without network access or credentials.
+// Running the test with the -githubtest.record flag instead
+// sends the requests to GitHub, using credentials from [github.Dial],
+// and rewrites the file with the responses.
+//
+// Recordings omit request credentials and all but a few response headers,
+// and they replace anything that looks like a GitHub token with "REDACTED".
+// Even so, recordings should be reviewed before they are checked in.
+package githubtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"rsc.io/github"
+)
+
+var record = flag.Bool("githubtest.record", false, "record GitHub API traffic instead of replaying it")
+
+// Client returns a client for use in the test t.
+// If the -githubtest.record flag is set, the client connects to GitHub
+// using credentials from [github.Dial] and records its traffic to file,
+// writing the file when the test finishes.
+// Otherwise, the client replays the traffic from file.
+func Client(t testing.TB, file string) *github.Client {
+	t.Helper()
+	r, err := NewRecorder(file, *record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := r.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	var c *github.Client
+	if *record {
+		c, err = github.Dial("")
+		if err != nil {
+			t.Fatal(err)
+		}
+	} else {
+		c = github.NewClient("")
+	}
+	return r.Client(c)
+}
+
+// An Entry is a single recorded request and response.
+type Entry struct {
+	Method   string
+	URL      string
+	Body     string `json:",omitempty"`
+	Status   int
+	Header   http.Header `json:",omitempty"`
+	Response string
+}
+
+// A Recorder is an [http.RoundTripper] that records or replays GitHub API traffic.
+type Recorder struct {
+	file      string
+	recording bool
+	rt        http.RoundTripper
+
+	mu      sync.Mutex
+	entries []*Entry
+	used    []bool
+}
+
+// NewRecorder returns a new Recorder for the named file.
+// If record is true, the recorder sends requests using [http.DefaultTransport]
+// and records them, writing file when [Recorder.Close] is called.
+// Otherwise, the recorder reads the entries in file
+// and answers requests by replaying them.
+func NewRecorder(file string, record bool) (*Recorder, error) {
+	r := &Recorder{file: file, recording: record, rt: http.DefaultTransport}
+	if record {
+		return r, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &r.entries); err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	r.used = make([]bool, len(r.entries))
+	return r, nil
+}
+
+// Client returns a copy of c that sends its requests through r.
+func (r *Recorder) Client(c *github.Client) *github.Client {
+	return c.WithHTTPClient(&http.Client{Transport: r})
+}
+
+// Close writes the recorded entries to the file, if recording.
+func (r *Recorder) Close() error {
+	if !r.recording {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	js, err := json.MarshalIndent(r.entries, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.file, append(js, '\n'), 0666)
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if r.recording {
+		return r.record(req, body)
+	}
+	return r.replay(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.rt.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	e := &Entry{
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Body:     scrub(string(body)),
+		Status:   resp.StatusCode,
+		Header:   make(http.Header),
+		Response: scrub(string(data)),
+	}
+	for _, k := range keepHeaders {
+		if v := resp.Header.Values(k); v != nil {
+			e.Header[k] = v
+		}
+	}
+	r.mu.Lock()
+	r.entries = append(r.entries, e)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// keepHeaders lists the response headers kept in recordings.
+var keepHeaders = []string{
+	"Content-Type",
+	"Link",
+	"Retry-After",
+	"X-Oauth-Scopes",
+	"X-Ratelimit-Limit",
+	"X-Ratelimit-Remaining",
+	"X-Ratelimit-Reset",
+	"X-Ratelimit-Used",
+}
+
+// tokenRE matches GitHub tokens.
+// See https://github.blog/2021-04-05-behind-githubs-new-authentication-token-formats/.
+var tokenRE = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)
+
+// scrub removes secrets from s.
+func scrub(s string) string {
+	return tokenRE.ReplaceAllString(s, "REDACTED")
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	url := req.URL.String()
+	b := scrub(string(body))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, e := range r.entries {
+		if r.used[i] || e.Method != req.Method || e.URL != url || e.Body != b {
+			continue
+		}
+		r.used[i] = true
+		header := e.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		return &http.Response{
+			Status:     fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+			StatusCode: e.Status,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(e.Response)),
+			Request:    req,
+		}, nil
+	}
+	return nil, errors.New("githubtest: no recorded response for " + req.Method + " " + url + "\n" + b)
+}
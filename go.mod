@@ -4,14 +4,15 @@ go 1.22.0
 
 require (
 	9fans.net/go v0.0.7
-	github.com/google/go-github v17.0.0+incompatible
-	golang.org/x/oauth2 v0.21.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
 	rsc.io/dbstore v0.1.1
 	rsc.io/sqlite v1.0.0
 	rsc.io/todo v0.0.3
 )
 
 require (
-	github.com/google/go-cmp v0.6.0 // indirect
-	github.com/google/go-querystring v1.1.0 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/otel/metric v1.28.0 // indirect
 )
@@ -0,0 +1,87 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// A RequestInfo describes a single HTTP request made by a Client,
+// as passed to the hooks set by [Client.WithOnRequest] and [Client.WithOnResponse].
+type RequestInfo struct {
+	Context context.Context // the Client's context (see [Client.WithContext])
+	Method  string
+	URL     string
+	Query   string // GraphQL query text; empty for REST requests
+	Vars    Vars   // GraphQL variables; nil for REST requests
+	Attempt int    // 1 for the first attempt, 2 for the first retry, and so on
+}
+
+// A ResponseInfo describes the outcome of a request made by a Client.
+type ResponseInfo struct {
+	Request    *RequestInfo
+	StatusCode int           // HTTP status code; 0 if there was no response
+	Duration   time.Duration // time from sending the request to reading the full response
+	Cost       int           // GraphQL rate limit cost; 0 if unknown or a REST request
+	Err        error         // error from the request, if any
+}
+
+// WithOnRequest returns a shallow copy of c that calls f before sending each HTTP request,
+// including retries. The hook must not modify the RequestInfo.
+// Passing a nil f removes any existing hook.
+func (c *Client) WithOnRequest(f func(*RequestInfo)) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.onRequest = f
+	return c2
+}
+
+// WithOnResponse returns a shallow copy of c that calls f after each HTTP request completes,
+// whether it succeeded or not. Passing a nil f removes any existing hook.
+//
+// By default, a Client logs the text of a GraphQL query that fails,
+// using the log package. A Client with an OnResponse hook does not;
+// the hook can log r.Request.Query itself when r.Err is non-nil.
+func (c *Client) WithOnResponse(f func(*ResponseInfo)) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.onResponse = f
+	return c2
+}
+
+// startRequest calls the OnRequest hook, if any, for the request described by info.
+// It returns a function to be called with the outcome of the request,
+// which calls the OnResponse hook and reports to the Metrics, if any.
+func (c *Client) startRequest(info *RequestInfo) func(resp *http.Response, cost int, err error) {
+	if c.onRequest != nil {
+		c.onRequest(info)
+	}
+	start := time.Now()
+	return func(resp *http.Response, cost int, err error) {
+		d := time.Since(start)
+		if c.metrics != nil {
+			api := "rest"
+			if info.Query != "" {
+				api = "graphql"
+			}
+			c.metrics.ObserveRequest(api, d, err)
+		}
+		if c.onResponse == nil {
+			return
+		}
+		r := &ResponseInfo{
+			Request:  info,
+			Duration: d,
+			Cost:     cost,
+			Err:      err,
+		}
+		if resp != nil {
+			r.StatusCode = resp.StatusCode
+		}
+		c.onResponse(r)
+	}
+}
@@ -0,0 +1,207 @@
+// Code generated by mkiface.go; DO NOT EDIT.
+
+package github
+
+import (
+	"net/http"
+	"time"
+
+	"rsc.io/github/schema"
+)
+
+// A ClientInterface is implemented by [*Client].
+// It lists the Client's methods for accessing GitHub,
+// so that code using a Client can be tested with a fake implementation.
+// It omits the methods that configure a Client, such as [Client.WithContext].
+//
+// New methods may be added to ClientInterface as they are added to Client.
+// Fakes should embed ClientInterface, leaving it nil,
+// and define only the methods they need.
+type ClientInterface interface {
+	AddIssueComment(issue *Issue, text string) error
+	AddIssueLabels(issue *Issue, labels ...*Label) error
+	AddProjectDraftIssue(p *Project, title, body string) (*ProjectItem, error)
+	// AddStar stars the repository as the authenticated user.
+	AddStar(r *Repo) error
+	ArchiveProjectItem(project *Project, item *ProjectItem) error
+	ArchiveRepo(r *Repo) error
+	// Artifacts returns the artifacts produced by the workflow run.
+	Artifacts(run *WorkflowRun) ([]*Artifact, error)
+	BranchProtectionRules(org, repo string) ([]*BranchProtectionRule, error)
+	// CheckSuites returns the check suites, including their check runs, for the commit identified by ref in org/repo.
+	CheckSuites(org, repo, ref string) ([]*CheckSuite, error)
+	CloseIssue(issue *Issue) error
+	// CodeScanningAlerts returns the code scanning alerts for org/repo.
+	CodeScanningAlerts(org, repo, state string) ([]*CodeScanningAlert, error)
+	// CommitStatus returns the combined status of the commit identified by ref in org/repo.
+	CommitStatus(org, repo, ref string) (*CommitStatus, error)
+	// Commits returns the history of commits reachable from ref in org/repo, most recent first.
+	Commits(org, repo, ref string, since, until time.Time) ([]*Commit, error)
+	// Compare compares the refs base and head in org/repo.
+	Compare(org, repo, base, head string) (*Comparison, error)
+	// CreateBranchProtectionRule adds the protection rule r to the repository.
+	CreateBranchProtectionRule(repo *Repo, r *BranchProtectionRule) (*BranchProtectionRule, error)
+	// CreateFork forks the repository into the organization org, or into the authenticated user's account if org is the empty string.
+	CreateFork(r *Repo, org string) (*Repo, error)
+	// CreateGist creates a new gist containing files, which maps file names to content.
+	CreateGist(description string, public bool, files map[string]string) (*Gist, error)
+	CreateIssue(repo *Repo, title, body string, extra ...any) (*Issue, error)
+	// CreateOrgRuleset adds the ruleset r to the organization org.
+	CreateOrgRuleset(org string, r *Ruleset) (*Ruleset, error)
+	CreateProject(org, title string) (*Project, error)
+	// CreateProjectField adds a new field with the given name and data type to the project.
+	CreateProjectField(p *Project, name string, dataType schema.ProjectV2FieldType, options ...string) (*ProjectField, error)
+	// CreateRepo creates a new repository named name owned by org.
+	CreateRepo(org, name, description string, visibility schema.RepositoryVisibility) (*Repo, error)
+	// CreateRepoRuleset adds the ruleset r to the repository.
+	CreateRepoRuleset(repo *Repo, r *Ruleset) (*Ruleset, error)
+	DeleteBranchProtectionRule(r *BranchProtectionRule) error
+	DeleteIssue(issue *Issue) error
+	DeleteProjectItem(project *Project, item *ProjectItem) error
+	DeleteRuleset(r *Ruleset) error
+	// DependabotAlerts returns the Dependabot alerts for org/repo.
+	DependabotAlerts(org, repo string, states ...schema.RepositoryVulnerabilityAlertState) ([]*DependabotAlert, error)
+	// Dependencies returns the packages in the dependency graph of org/repo, as listed in its SBOM.
+	Dependencies(org, repo string) ([]*Dependency, error)
+	// DeploymentStatuses returns the statuses of the deployment, oldest first.
+	DeploymentStatuses(d *Deployment) ([]*DeploymentStatus, error)
+	// Deployments returns the deployments in org/repo, most recent first.
+	Deployments(org, repo string, environments ...string) ([]*Deployment, error)
+	// DisablePullRequestAutoMerge disables auto-merge for the pull request.
+	DisablePullRequestAutoMerge(pr *PullRequest) error
+	Discussions(org, repo string) ([]*Discussion, error)
+	// DismissCodeScanningAlert dismisses the alert.
+	DismissCodeScanningAlert(a *CodeScanningAlert, reason, comment string) error
+	// DownloadArtifact returns the content of the artifact, a zip file.
+	DownloadArtifact(a *Artifact) ([]byte, error)
+	EditIssueComment(comment *IssueComment, body string) error
+	// EnablePullRequestAutoMerge enables auto-merge for the pull request, so that it is merged using method (MERGE, SQUASH, or REBASE) once all its requirements are met.
+	EnablePullRequestAutoMerge(pr *PullRequest, method schema.PullRequestMergeMethod) error
+	// Environments returns the deployment environments in org/repo.
+	Environments(org, repo string) ([]*Environment, error)
+	// FileContent returns the content of the file with the given path in org/repo at ref, which can be a branch name, tag name, or commit hash.
+	FileContent(org, repo, ref, path string) (*Blob, error)
+	// Forks returns the direct forks of org/repo.
+	Forks(org, repo string) ([]*Repo, error)
+	// Gist returns the gist with the given ID.
+	Gist(id string) (*Gist, error)
+	// GraphQLMutation runs a single mutation with the bound variables.
+	GraphQLMutation(query string, vars Vars) (*schema.Mutation, error)
+	// GraphQLQuery runs a single query with the bound variables.
+	GraphQLQuery(query string, vars Vars) (*schema.Query, error)
+	// HTTPClient returns the HTTP client that c uses for its requests: the one set by [Client.WithHTTPClient], or else [http.DefaultClient].
+	HTTPClient() *http.Client
+	Issue(org, repo string, n int) (*Issue, error)
+	IssueComments(issue *Issue) ([]*IssueComment, error)
+	// IssuesByNumber returns the issues in org/repo with the given numbers, in the same order as numbers.
+	IssuesByNumber(org, repo string, numbers []int) ([]*Issue, error)
+	// MarkNotificationRead marks the notification thread as read.
+	MarkNotificationRead(n *Notification) error
+	// MarkThreadDone marks the notification thread as done, removing it from the inbox.
+	MarkThreadDone(n *Notification) error
+	// MergeQueue returns the entries in the merge queue for the branch in org/repo, in queue order.
+	MergeQueue(org, repo, branch string) ([]*MergeQueueEntry, error)
+	// MilestoneIssues returns the issues in the given milestone of org/repo.
+	MilestoneIssues(org, repo string, milestone *Milestone, state string) ([]*Issue, error)
+	// NewBatch returns a new, empty batch of queries to run using c.
+	NewBatch() *Batch
+	// Notifications returns the authenticated user's notifications, most recently updated first.
+	Notifications(opts *NotificationOptions) ([]*Notification, error)
+	// OrgInvitations returns the pending invitations to join the organization.
+	OrgInvitations(org string) ([]*OrgInvitation, error)
+	// OrgMembers returns the members of the organization.
+	OrgMembers(org string) ([]*OrgMember, error)
+	// OrgRulesets returns the rulesets defined by the organization org.
+	OrgRulesets(org string) ([]*Ruleset, error)
+	ProjectItems(p *Project) ([]*ProjectItem, error)
+	Projects(org, query string) ([]*Project, error)
+	// PullRequest returns the pull request in org/repo with number n.
+	PullRequest(org, repo string, n int) (*PullRequest, error)
+	// PullRequestQueueEntry returns the pull request's merge queue entry, or nil if the pull request is not in a merge queue.
+	PullRequestQueueEntry(pr *PullRequest) (*MergeQueueEntry, error)
+	// REST makes a REST API request using c's credentials and settings, including its retry policy for rate-limited requests.
+	REST(method, path string, body, reply any) error
+	// RESTList fetches all pages of a REST API list result (subject to the limits set by [Client.WithPageSize] and [Client.WithLimit]) and decodes the combined results into list, which must be a pointer to a slice.
+	RESTList(path, field string, list any) error
+	// RateLimit returns the GraphQL API rate limit as of the most recent request made by c or any of the copies made by its With methods.
+	RateLimit() *RateLimit
+	// Refs returns the Git references in org/repo with names beginning with prefix, such as "refs/heads/" or "refs/tags/".
+	Refs(org, repo, prefix string) ([]*Ref, error)
+	RemilestoneIssue(issue *Issue, milestone *Milestone) error
+	RemoveIssueLabels(issue *Issue, labels ...*Label) error
+	// RemoveStar unstars the repository as the authenticated user.
+	RemoveStar(r *Repo) error
+	// ReopenCodeScanningAlert reopens a dismissed alert.
+	ReopenCodeScanningAlert(a *CodeScanningAlert) error
+	ReopenIssue(issue *Issue) error
+	// ReopenSecretScanningAlert reopens a resolved alert.
+	ReopenSecretScanningAlert(a *SecretScanningAlert) error
+	Repo(org, repo string) (*Repo, error)
+	// RepoProjects returns the projects linked to the repository org/repo.
+	RepoProjects(org, repo, query string) ([]*Project, error)
+	// RepoRulesets returns the rulesets that apply to the repository org/repo, including rulesets defined by the organization.
+	RepoRulesets(org, repo string) ([]*Ruleset, error)
+	// RepoSecurityAdvisories returns the security advisories for org/repo that are visible to the authenticated user.
+	RepoSecurityAdvisories(org, repo string) ([]*SecurityAdvisory, error)
+	// Repos returns the repositories owned by the organization org.
+	Repos(org string) ([]*Repo, error)
+	// ResolveSecretScanningAlert resolves the alert.
+	ResolveSecretScanningAlert(a *SecretScanningAlert, resolution, comment string) error
+	RetitleIssue(issue *Issue, title string) error
+	// SBOM returns the software bill of materials for org/repo, derived from its dependency graph, as an SPDX JSON document.
+	SBOM(org, repo string) ([]byte, error)
+	// SearchCode returns the files matching the code search query, which uses GitHub's code search syntax, as in "Setenv repo:golang/go language:go".
+	SearchCode(query string) ([]*CodeMatch, error)
+	SearchLabels(org, repo, query string) ([]*Label, error)
+	SearchMilestones(org, repo, query string) ([]*Milestone, error)
+	// SecretScanningAlerts returns the secret scanning alerts for org/repo.
+	SecretScanningAlerts(org, repo, state string) ([]*SecretScanningAlert, error)
+	SetProjectItemFieldDate(project *Project, item *ProjectItem, field *ProjectField, date time.Time) error
+	SetProjectItemFieldIteration(project *Project, item *ProjectItem, field *ProjectField, iteration *ProjectIteration) error
+	SetProjectItemFieldNumber(project *Project, item *ProjectItem, field *ProjectField, number float64) error
+	SetProjectItemFieldOption(project *Project, item *ProjectItem, field *ProjectField, option *ProjectFieldOption) error
+	SetProjectItemFieldText(project *Project, item *ProjectItem, field *ProjectField, text string) error
+	// SetProjectItemFieldValue sets the value of field for the given project item.
+	SetProjectItemFieldValue(project *Project, item *ProjectItem, field *ProjectField, value any) error
+	// Stargazers returns the number of stargazers of org/repo and the n users who starred it most recently, newest first.
+	Stargazers(org, repo string, n int) (int, []*Stargazer, error)
+	// SubscribeToIssue subscribes the authenticated user to notifications about the issue.
+	SubscribeToIssue(issue *Issue) error
+	// Team returns the team in org with the given slug.
+	Team(org, team string) (*Team, error)
+	// TeamMembers returns the members of the team in org with the given slug, including members of its child teams.
+	TeamMembers(org, team string) ([]*TeamMember, error)
+	// TeamRepos returns the repositories that the team in org with the given slug can access.
+	TeamRepos(org, team string) ([]*TeamRepo, error)
+	// Teams returns the teams in the organization visible to the authenticated user.
+	Teams(org string) ([]*Team, error)
+	UnarchiveProjectItem(project *Project, item *ProjectItem) error
+	// UnsubscribeFromIssue unsubscribes the authenticated user from notifications about the issue.
+	UnsubscribeFromIssue(issue *Issue) error
+	// UpdateBranchProtectionRule replaces the settings of the existing rule identified by r.ID with the settings in r.
+	UpdateBranchProtectionRule(r *BranchProtectionRule) (*BranchProtectionRule, error)
+	// UpdateGist updates the gist's description and files, returning the new state of the gist.
+	UpdateGist(g *Gist, description string, files map[string]string) (*Gist, error)
+	UpdateProject(p *Project, settings *ProjectSettings) (*Project, error)
+	UpdateRepo(r *Repo, settings *RepoSettings) (*Repo, error)
+	// UpdateRuleset replaces the settings of the existing ruleset identified by r.ID with the settings in r.
+	UpdateRuleset(r *Ruleset) (*Ruleset, error)
+	UserComments(user string) ([]*IssueComment, error)
+	// UserGists returns the gists owned by user that are visible to the authenticated user.
+	UserGists(user string) ([]*Gist, error)
+	UserProjects(user, query string) ([]*Project, error)
+	// Verify checks that c's token is valid, by making a cheap authenticated request, and reports the token's login and type.
+	Verify(scopes ...string) (*TokenInfo, error)
+	// Watch polls org/repo for changes to issues and issue comments, delivering them on the returned channel, oldest first.
+	Watch(org, repo string, opts *WatchOptions) <-chan *Change
+	// WatchRepo sets the authenticated user's notification subscription to the repository: SUBSCRIBED to watch all activity, UNSUBSCRIBED to be notified only when participating or mentioned, or IGNORED to never be notified.
+	WatchRepo(r *Repo, state schema.SubscriptionState) error
+	// WorkflowJobs returns the jobs in the most recent attempt of the workflow run.
+	WorkflowJobs(run *WorkflowRun) ([]*WorkflowJob, error)
+	// WorkflowRun returns the workflow run with the given ID.
+	WorkflowRun(org, repo string, id int64) (*WorkflowRun, error)
+	// WorkflowRuns returns the workflow runs in the repository org/repo, most recent first.
+	WorkflowRuns(org, repo string, opts *WorkflowRunOptions) ([]*WorkflowRun, error)
+}
+
+var _ ClientInterface = (*Client)(nil)
@@ -18,14 +18,25 @@ import (
 type Client struct {
 	user   string
 	passwd string
+	url    string
 }
 
 func Dial() (*Client, error) {
-	user, passwd, err := netrcAuth("api.github.com")
+	return DialHost("api.github.com")
+}
+
+// DialHost is like Dial but connects to the GitHub Enterprise Server
+// instance at host, using the netrc entry for host.
+func DialHost(host string) (*Client, error) {
+	user, passwd, err := netrcAuth(host)
 	if err != nil {
 		return nil, err
 	}
-	return &Client{user: user, passwd: passwd}, nil
+	url := "https://api.github.com/graphql"
+	if host != "api.github.com" && host != "github.com" {
+		url = "https://" + host + "/api/graphql"
+	}
+	return &Client{user: user, passwd: passwd, url: url}, nil
 }
 
 type Vars map[string]any
@@ -49,7 +60,7 @@ Retry:
 		method = "GET"
 		js = nil
 	}
-	req, err := http.NewRequest(method, "https://api.github.com/graphql", body)
+	req, err := http.NewRequest(method, c.url, body)
 	if err != nil {
 		return err
 	}
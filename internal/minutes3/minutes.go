@@ -67,7 +67,7 @@ func main() {
 }
 
 type Reporter struct {
-	Client    *github.Client
+	Client    github.ClientInterface
 	Proposals *github.Project
 	Items     map[int]*github.ProjectItem
 	Labels    map[string]*github.Label
@@ -507,10 +507,10 @@ func markdownEscape(s string) string {
 func (r *Reporter) RetireOld() {
 	for _, item := range r.Items {
 		issue := item.Issue
-		if issue.Closed && !issue.ClosedAt.IsZero() && time.Since(issue.ClosedAt) > 365*24*time.Hour {
+		if !item.IsArchived && issue.Closed && !issue.ClosedAt.IsZero() && time.Since(issue.ClosedAt) > 365*24*time.Hour {
 			log.Printf("retire #%d", issue.Number)
-			if err := r.Client.DeleteProjectItem(r.Proposals, item); err != nil {
-				log.Printf("#%d: deleting proposal item: %v", issue.Number, err)
+			if err := r.Client.ArchiveProjectItem(r.Proposals, item); err != nil {
+				log.Printf("#%d: archiving proposal item: %v", issue.Number, err)
 			}
 		}
 	}
@@ -5,7 +5,9 @@
 package github
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"rsc.io/github/schema"
@@ -18,13 +20,20 @@ const issueFields = `
   author { __typename login }
   closed
   closedAt
+  stateReason
   createdAt
+  updatedAt
   lastEditedAt
   milestone { id number title }
   repository { name owner { __typename login } }
   body
   url
+  comments { totalCount }
   labels(first: 100) {
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
     nodes {
       name
       description
@@ -53,14 +62,81 @@ func (c *Client) Issue(org, repo string, n int) (*Issue, error) {
 		return nil, err
 	}
 	issue := toIssue(q.Organization.Repository.Issue)
+	if err := c.moreIssueLabels([]*Issue{issue}); err != nil {
+		return nil, err
+	}
 	return issue, nil
 }
 
+// IssuesByNumber returns the issues in org/repo with the given numbers,
+// in the same order as numbers.
+// It fetches many issues in each GraphQL request, using aliases.
+// If some issues cannot be fetched, as when a number does not exist,
+// the list has nil entries for those issues, and the error
+// lists the numbers that failed, in the order given.
+func (c *Client) IssuesByNumber(org, repo string, numbers []int) ([]*Issue, error) {
+	const batch = 50
+	var list, found []*Issue
+	var errs []error
+	for len(numbers) > 0 {
+		n := min(len(numbers), batch)
+		var buf strings.Builder
+		for i, num := range numbers[:n] {
+			fmt.Fprintf(&buf, "i%d: issue(number: %d) {\n%s\n}\n", i, num, issueFields)
+		}
+		graphql := `
+		  query($Org: String!, $Repo: String!) {
+		    repository(owner: $Org, name: $Repo) {
+		      ` + buf.String() + `
+		    }
+		  }
+		`
+		var reply struct {
+			Repository map[string]*schema.Issue
+		}
+		err := c.graphQL(graphql, Vars{"Org": org, "Repo": repo}, &reply)
+		gerrs, ok := err.(GraphQLErrors)
+		if err != nil && (!ok || !gerrs.Partial()) {
+			return list, err
+		}
+		for i, num := range numbers[:n] {
+			alias := fmt.Sprintf("i%d", i)
+			s := reply.Repository[alias]
+			if s == nil {
+				errs = append(errs, issueError(org, repo, num, alias, gerrs))
+				list = append(list, nil)
+				continue
+			}
+			issue := toIssue(s)
+			list = append(list, issue)
+			found = append(found, issue)
+		}
+		numbers = numbers[n:]
+	}
+	if err := c.moreIssueLabels(found); err != nil {
+		errs = append(errs, err)
+	}
+	return list, errors.Join(errs...)
+}
+
+// issueError returns the error for issue org/repo#num,
+// which is missing from the reply at repository.alias.
+// It is the GraphQL error for that path, if any,
+// or else a “no such issue” error.
+func issueError(org, repo string, num int, alias string, gerrs GraphQLErrors) error {
+	for _, e := range gerrs {
+		if len(e.Path) >= 2 && e.Path[0] == "repository" && e.Path[1] == alias {
+			return fmt.Errorf("%s/%s#%d: %v", org, repo, num, e)
+		}
+	}
+	return fmt.Errorf("%s/%s#%d: no such issue", org, repo, num)
+}
+
 func (c *Client) SearchLabels(org, repo, query string) ([]*Label, error) {
 	graphql := `
-	  query($Org: String!, $Repo: String!, $Query: String, $Cursor: String) {
+	  query($Org: String!, $Repo: String!, $Query: String, $Cursor: String, $PageSize: Int = 100) {
 	    repository(owner: $Org, name: $Repo) {
-	      labels(first: 100, query: $Query, after: $Cursor) {
+	      labels(first: $PageSize, query: $Query, after: $Cursor) {
 	        pageInfo {
 	          hasNextPage
 	          endCursor
@@ -88,9 +164,9 @@ func (c *Client) SearchLabels(org, repo, query string) ([]*Label, error) {
 
 func (c *Client) Discussions(org, repo string) ([]*Discussion, error) {
 	graphql := `
-	  query($Org: String!, $Repo: String!, $Cursor: String) {
+	  query($Org: String!, $Repo: String!, $Cursor: String, $PageSize: Int = 100) {
 	    repository(owner: $Org, name: $Repo) {
-	      discussions(first: 100, after: $Cursor) {
+	      discussions(first: $PageSize, after: $Cursor) {
 	        pageInfo {
 	          hasNextPage
 	          endCursor
@@ -116,9 +192,9 @@ func (c *Client) Discussions(org, repo string) ([]*Discussion, error) {
 
 func (c *Client) SearchMilestones(org, repo, query string) ([]*Milestone, error) {
 	graphql := `
-	  query($Org: String!, $Repo: String!, $Query: String, $Cursor: String) {
+	  query($Org: String!, $Repo: String!, $Query: String, $Cursor: String, $PageSize: Int = 100) {
 	    repository(owner: $Org, name: $Repo) {
-	      milestones(first: 100, query: $Query, after: $Cursor) {
+	      milestones(first: $PageSize, query: $Query, after: $Cursor) {
 	        pageInfo {
 	          hasNextPage
 	          endCursor
@@ -143,12 +219,64 @@ func (c *Client) SearchMilestones(org, repo, query string) ([]*Milestone, error)
 	)
 }
 
+// MilestoneIssues returns the issues in the given milestone of org/repo.
+// The state can be "open" or "closed" to restrict the result to open or closed issues,
+// or "" to return all issues.
+func (c *Client) MilestoneIssues(org, repo string, milestone *Milestone, state string) ([]*Issue, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Milestone: Int!, $States: [IssueState!], $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      milestone(number: $Milestone) {
+	        issues(first: $PageSize, states: $States, after: $Cursor) {
+	          pageInfo {
+	            hasNextPage
+	            endCursor
+	          }
+	          totalCount
+	          nodes {
+	            ` + issueFields + `
+	          }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	vars := Vars{"Org": org, "Repo": repo, "Milestone": milestone.Number}
+	switch state {
+	default:
+		return nil, fmt.Errorf("invalid issue state %q", state)
+	case "":
+	case "open":
+		vars["States"] = []schema.IssueState{schema.IssueState_OPEN}
+	case "closed":
+		vars["States"] = []schema.IssueState{schema.IssueState_CLOSED}
+	}
+	var noMilestone error
+	list, err := collect(c, graphql, vars, toIssue,
+		func(q *schema.Query) pager[*schema.Issue] {
+			if q.Repository == nil || q.Repository.Milestone == nil || q.Repository.Milestone.Issues == nil {
+				noMilestone = fmt.Errorf("%s/%s: no milestone %d", org, repo, milestone.Number)
+				return nil
+			}
+			return q.Repository.Milestone.Issues
+		},
+	)
+	if err == nil {
+		err = noMilestone
+	}
+	if err != nil {
+		return list, err
+	}
+	return list, c.moreIssueLabels(list)
+}
+
 func (c *Client) IssueComments(issue *Issue) ([]*IssueComment, error) {
 	graphql := `
-	  query($Org: String!, $Repo: String!, $Number: Int!, $Cursor: String) {
+	  query($Org: String!, $Repo: String!, $Number: Int!, $Cursor: String, $PageSize: Int = 100) {
 	    repository(owner: $Org, name: $Repo) {
 	      issue(number: $Number) {
-	        comments(first: 100, after: $Cursor) {
+	        comments(first: $PageSize, after: $Cursor) {
 	          pageInfo {
 	            hasNextPage
 	            endCursor
@@ -178,9 +306,9 @@ func (c *Client) IssueComments(issue *Issue) ([]*IssueComment, error) {
 
 func (c *Client) UserComments(user string) ([]*IssueComment, error) {
 	graphql := `
-	  query($User: String!, $Cursor: String) {
+	  query($User: String!, $Cursor: String, $PageSize: Int = 100) {
 	    user(login: $User) {
-	      issueComments(first: 100, after: $Cursor) {
+	      issueComments(first: $PageSize, after: $Cursor) {
 	        pageInfo {
 	          hasNextPage
 	          endCursor
@@ -243,6 +371,28 @@ func (c *Client) ReopenIssue(issue *Issue) error {
 	return err
 }
 
+// SubscribeToIssue subscribes the authenticated user to notifications about the issue.
+func (c *Client) SubscribeToIssue(issue *Issue) error {
+	return c.updateSubscription(issue.ID, schema.SubscriptionState_SUBSCRIBED)
+}
+
+// UnsubscribeFromIssue unsubscribes the authenticated user from notifications about the issue.
+func (c *Client) UnsubscribeFromIssue(issue *Issue) error {
+	return c.updateSubscription(issue.ID, schema.SubscriptionState_UNSUBSCRIBED)
+}
+
+func (c *Client) updateSubscription(id string, state schema.SubscriptionState) error {
+	graphql := `
+	  mutation($ID: ID!, $State: SubscriptionState!) {
+	    updateSubscription(input: {subscribableId: $ID, state: $State}) {
+	      clientMutationId
+	    }
+	  }
+	`
+	_, err := c.GraphQLMutation(graphql, Vars{"ID": id, "State": state})
+	return err
+}
+
 func (c *Client) AddIssueLabels(issue *Issue, labels ...*Label) error {
 	var labelIDs []string
 	for _, lab := range labels {
@@ -276,7 +426,7 @@ func (c *Client) RemoveIssueLabels(issue *Issue, labels ...*Label) error {
 }
 
 func (c *Client) CreateIssue(repo *Repo, title, body string, extra ...any) (*Issue, error) {
-	var labelIDs []string
+	labelIDs := []string{} // not nil: $Labels is non-null
 	var projectIDs []string
 	for _, x := range extra {
 		switch x := x.(type) {
@@ -298,10 +448,13 @@ func (c *Client) CreateIssue(repo *Repo, title, body string, extra ...any) (*Iss
 	    }
 	  }
 	`
-	m, err := c.GraphQLMutation(graphql, Vars{"Repo": repo.ID, "Title": title, "Body": body, "Labels": labelIDs, "Projects": projectIDs})
+	m, err := c.GraphQLMutation(graphql, Vars{"Repo": repo.ID, "Title": title, "Body": body, "Labels": labelIDs})
 	if err != nil {
 		return nil, err
 	}
+	if m.CreateIssue == nil || m.CreateIssue.Issue == nil {
+		return nil, c.noResult("createIssue")
+	}
 	issue := toIssue(m.CreateIssue.Issue)
 	for _, id := range projectIDs {
 		graphql := `
@@ -379,6 +532,55 @@ func (c *Client) SetProjectItemFieldOption(project *Project, item *ProjectItem,
 	return err
 }
 
+func (c *Client) SetProjectItemFieldText(project *Project, item *ProjectItem, field *ProjectField, text string) error {
+	return c.SetProjectItemFieldValue(project, item, field, text)
+}
+
+func (c *Client) SetProjectItemFieldNumber(project *Project, item *ProjectItem, field *ProjectField, number float64) error {
+	return c.SetProjectItemFieldValue(project, item, field, number)
+}
+
+func (c *Client) SetProjectItemFieldDate(project *Project, item *ProjectItem, field *ProjectField, date time.Time) error {
+	return c.SetProjectItemFieldValue(project, item, field, date)
+}
+
+func (c *Client) SetProjectItemFieldIteration(project *Project, item *ProjectItem, field *ProjectField, iteration *ProjectIteration) error {
+	return c.SetProjectItemFieldValue(project, item, field, iteration)
+}
+
+// SetProjectItemFieldValue sets the value of field for the given project item.
+// The value must be a string (for a text field), a float64 or int (for a number field),
+// a time.Time (for a date field), a *ProjectFieldOption (for a single-select field),
+// or a *ProjectIteration (for an iteration field).
+func (c *Client) SetProjectItemFieldValue(project *Project, item *ProjectItem, field *ProjectField, value any) error {
+	var v map[string]any
+	switch value := value.(type) {
+	default:
+		return fmt.Errorf("cannot set project field to value of type %T", value)
+	case string:
+		v = map[string]any{"text": value}
+	case float64:
+		v = map[string]any{"number": value}
+	case int:
+		v = map[string]any{"number": float64(value)}
+	case time.Time:
+		v = map[string]any{"date": value.Format("2006-01-02")}
+	case *ProjectFieldOption:
+		v = map[string]any{"singleSelectOptionId": value.ID}
+	case *ProjectIteration:
+		v = map[string]any{"iterationId": value.ID}
+	}
+	graphql := `
+	  mutation($Project: ID!, $Item: ID!, $Field: ID!, $Value: ProjectV2FieldValue!) {
+	    updateProjectV2ItemFieldValue(input: {projectId: $Project, itemId: $Item, fieldId: $Field, value: $Value}) {
+	      clientMutationId
+	    }
+	  }
+	`
+	_, err := c.GraphQLMutation(graphql, Vars{"Project": project.ID, "Item": item.ID, "Field": field.ID, "Value": v})
+	return err
+}
+
 func (c *Client) DeleteProjectItem(project *Project, item *ProjectItem) error {
 	graphql := `
 	  mutation($Project: ID!, $Item: ID!) {
@@ -391,6 +593,36 @@ func (c *Client) DeleteProjectItem(project *Project, item *ProjectItem) error {
 	return err
 }
 
+func (c *Client) ArchiveProjectItem(project *Project, item *ProjectItem) error {
+	graphql := `
+	  mutation($Project: ID!, $Item: ID!) {
+	    archiveProjectV2Item(input: {projectId: $Project, itemId: $Item}) {
+	      clientMutationId
+	    }
+	  }
+	`
+	_, err := c.GraphQLMutation(graphql, Vars{"Project": project.ID, "Item": item.ID})
+	if err == nil {
+		item.IsArchived = true
+	}
+	return err
+}
+
+func (c *Client) UnarchiveProjectItem(project *Project, item *ProjectItem) error {
+	graphql := `
+	  mutation($Project: ID!, $Item: ID!) {
+	    unarchiveProjectV2Item(input: {projectId: $Project, itemId: $Item}) {
+	      clientMutationId
+	    }
+	  }
+	`
+	_, err := c.GraphQLMutation(graphql, Vars{"Project": project.ID, "Item": item.ID})
+	if err == nil {
+		item.IsArchived = false
+	}
+	return err
+}
+
 type Label struct {
 	Name        string
 	Description string
@@ -444,8 +676,9 @@ func toDiscussion(s *schema.Discussion) *Discussion {
 }
 
 type Milestone struct {
-	Title string
-	ID    string
+	Title  string
+	ID     string
+	Number int
 }
 
 func toMilestone(s *schema.Milestone) *Milestone {
@@ -453,8 +686,9 @@ func toMilestone(s *schema.Milestone) *Milestone {
 		return nil
 	}
 	return &Milestone{
-		Title: s.Title,
-		ID:    string(s.Id),
+		Title:  s.Title,
+		ID:     string(s.Id),
+		Number: s.Number,
 	}
 }
 
@@ -464,7 +698,9 @@ type Issue struct {
 	Number       int
 	Closed       bool
 	ClosedAt     time.Time
+	StateReason  schema.IssueStateReason
 	CreatedAt    time.Time
+	UpdatedAt    time.Time
 	LastEditedAt time.Time
 	Labels       []*Label
 	Milestone    *Milestone
@@ -473,17 +709,22 @@ type Issue struct {
 	Repo         string
 	Body         string
 	URL          string
+	CommentCount int
+
+	moreLabels string // cursor for fetching remaining labels
 }
 
 func toIssue(s *schema.Issue) *Issue {
-	return &Issue{
+	issue := &Issue{
 		ID:           string(s.Id),
 		Title:        s.Title,
 		Number:       s.Number,
 		Author:       toAuthor(&s.Author),
 		Closed:       s.Closed,
 		ClosedAt:     toTime(s.ClosedAt),
+		StateReason:  s.StateReason,
 		CreatedAt:    toTime(s.CreatedAt),
+		UpdatedAt:    toTime(s.UpdatedAt),
 		LastEditedAt: toTime(s.LastEditedAt),
 		Owner:        toOwner(&s.Repository.Owner),
 		Repo:         s.Repository.Name,
@@ -492,6 +733,53 @@ func toIssue(s *schema.Issue) *Issue {
 		Body:         s.Body,
 		URL:          string(s.Url),
 	}
+	if s.Comments != nil {
+		issue.CommentCount = s.Comments.TotalCount
+	}
+	if s.Labels != nil && s.Labels.PageInfo.HasNextPage {
+		issue.moreLabels = s.Labels.PageInfo.EndCursor
+	}
+	return issue
+}
+
+// moreIssueLabels fetches the remaining labels for any issues
+// that had too many labels to fetch in the original query.
+func (c *Client) moreIssueLabels(list []*Issue) error {
+	connection := `
+	  labels(first: $PageSize, after: $Cursor) {
+	    pageInfo {
+	      hasNextPage
+	      endCursor
+	    }
+	    totalCount
+	    nodes {
+	      name
+	      description
+	      id
+	      repository { name owner { __typename login } }
+	    }
+	  }
+	`
+
+	for _, issue := range list {
+		if issue.moreLabels == "" {
+			continue
+		}
+		labels, err := collectMore(c, issue.ID, "Issue", issue.moreLabels, connection, toLabel,
+			func(si *schema.Issue) pager[*schema.Label] {
+				if si.Labels == nil {
+					return nil
+				}
+				return si.Labels
+			},
+		)
+		issue.Labels = append(issue.Labels, labels...)
+		if err != nil {
+			return err
+		}
+		issue.moreLabels = ""
+	}
+	return nil
 }
 
 func (i *Issue) LabelByName(name string) *Label {
@@ -528,25 +816,3 @@ func toIssueComment(s *schema.IssueComment) *IssueComment {
 		Repo:        s.Repository.Name,
 	}
 }
-
-type Repo struct {
-	Owner string
-	Repo  string
-	ID    string
-}
-
-func (c *Client) Repo(org, repo string) (*Repo, error) {
-	graphql := `
-	  query($Org: String!, $Repo: String!) {
-	    repository(owner: $Org, name: $Repo) {
-	      id
-	    }
-	  }
-	`
-	vars := Vars{"Org": org, "Repo": repo}
-	q, err := c.GraphQLQuery(graphql, vars)
-	if err != nil {
-		return nil, err
-	}
-	return &Repo{org, repo, string(q.Repository.Id)}, nil
-}
@@ -9,11 +9,9 @@ package main
 import (
 	"bufio"
 	"bytes"
-	"context"
 	"flag"
 	"fmt"
 	"log"
-	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -22,7 +20,8 @@ import (
 
 	"9fans.net/go/acme"
 	"9fans.net/go/plumb"
-	"github.com/google/go-github/v62/github"
+	"rsc.io/github"
+	"rsc.io/github/schema"
 )
 
 func (w *awin) project() string {
@@ -40,7 +39,7 @@ func (w *awin) project() string {
 
 func acmeMode() {
 	var dummy awin
-	dummy.prefix = "/issue/" + *project + "/"
+	dummy.prefix = "/issue/" + project + "/"
 	if flag.NArg() > 0 {
 		// TODO(rsc): Without -a flag, the query is conatenated into one query.
 		// Decide which behavior should be used, and use it consistently.
@@ -130,7 +129,7 @@ type awin struct {
 	mode         int
 	query        string
 	id           int
-	github       *github.Issue
+	github       *schema.Issue
 	title        string
 	sortByNumber bool // otherwise sort by title
 }
@@ -147,7 +146,7 @@ func (w *awin) exit() {
 		delete(all.m, w.Win)
 	}
 	if len(all.m) == 0 {
-		os.Exit(0)
+		exit(0)
 	}
 }
 
@@ -166,7 +165,7 @@ func (w *awin) new(prefix, title string) *awin {
 		time.Sleep(10 * time.Millisecond)
 		w1.Win, err = acme.New()
 		if err != nil {
-			log.Fatalf("creating acme window again: %v", err)
+			fatalf("creating acme window again: %v", err)
 		}
 	}
 	w1.prefix = prefix
@@ -185,13 +184,13 @@ var repoHashRE = regexp.MustCompile(`\A([A-Za-z0-9_]+/[A-Za-z0-9_]+)#(all|[0-9]+
 
 var milecache struct {
 	sync.Mutex
-	list map[string][]*github.Milestone
+	list map[string][]*schema.Milestone
 }
 
-func cachedMilestones(project string) []*github.Milestone {
+func cachedMilestones(project string) []*schema.Milestone {
 	milecache.Lock()
 	if milecache.list == nil {
-		milecache.list = make(map[string][]*github.Milestone)
+		milecache.list = make(map[string][]*schema.Milestone)
 	}
 	if milecache.list[project] == nil {
 		milecache.list[project], _ = loadMilestones(project)
@@ -230,7 +229,7 @@ func (w *awin) Look(text string) bool {
 	}
 	list := cachedMilestones(w.project())
 	for _, m := range list {
-		if getString(m.Title) == text {
+		if m.Title == text {
 			if w.show(text) {
 				return true
 			}
@@ -275,44 +274,43 @@ func (w *awin) Look(text string) bool {
 	return false
 }
 
-func (w *awin) setMilestone(milestone, text string) {
+func (w *awin) setMilestone(name, text string) {
 	var buf bytes.Buffer
-	id := findMilestone(&buf, w.project(), &milestone)
+	milestone := findMilestone(&buf, w.project(), &name)
 	if buf.Len() > 0 {
 		w.Err(strings.TrimSpace(buf.String()))
 	}
-	if id == nil {
+	if milestone == nil {
 		return
 	}
-	milestoneID := *id
 
 	stop := w.Blink()
 	defer stop()
 	if w.mode == modeSingle {
-		w.setMilestone1(milestoneID, w.id)
+		w.setMilestone1(milestone, w.id)
 		w.load()
 		return
 	}
 	if n, _ := strconv.Atoi(strings.TrimPrefix(text, "#")); 0 < n && n < 100000 {
-		w.setMilestone1(milestoneID, n)
+		w.setMilestone1(milestone, n)
 		return
 	}
 	if m := numRE.FindAllString(text, -1); m != nil {
 		for _, s := range m {
 			n, _ := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(s, "#")))
 			if 0 < n && n < 100000 {
-				w.setMilestone1(milestoneID, n)
+				w.setMilestone1(milestone, n)
 			}
 		}
 		return
 	}
 }
 
-func (w *awin) setMilestone1(milestoneID, n int) {
-	var edit github.IssueRequest
-	edit.Milestone = &milestoneID
-
-	_, _, err := client.Issues.Edit(context.TODO(), projectOwner(w.project()), projectRepo(w.project()), n, &edit)
+func (w *awin) setMilestone1(m *schema.Milestone, n int) {
+	issues, err := bulkReadIssuesCached(w.project(), []int{n})
+	if err == nil {
+		err = client.RemilestoneIssue(&github.Issue{ID: string(issues[0].Id)}, &github.Milestone{ID: string(m.Id)})
+	}
 	if err != nil {
 		w.Err(fmt.Sprintf("Error changing issue #%d: %v", n, err))
 	}
@@ -405,7 +403,7 @@ func (w *awin) load() {
 		milestones, err := loadMilestones(w.project())
 		milecache.Lock()
 		if milecache.list == nil {
-			milecache.list = make(map[string][]*github.Milestone)
+			milecache.list = make(map[string][]*schema.Milestone)
 		}
 		milecache.list[w.project()] = milestones
 		milecache.Unlock()
@@ -417,7 +415,7 @@ func (w *awin) load() {
 		}
 		var buf bytes.Buffer
 		for _, m := range milestones {
-			fmt.Fprintf(&buf, "%s\t%s\t%d\n", getTime(m.DueOn).Format("2006-01-02"), getString(m.Title), getInt(m.OpenIssues))
+			fmt.Fprintf(&buf, "%s\t%s\t%d\n", getTime(m.DueOn).Format("2006-01-02"), m.Title, z(m.Issues).TotalCount)
 		}
 		w.PrintTabbed(buf.String())
 		w.Ctl("clean")
@@ -425,7 +423,10 @@ func (w *awin) load() {
 	case modeQuery:
 		var buf bytes.Buffer
 		stop := w.Blink()
-		err := showQuery(&buf, w.project(), w.query)
+		q, err := expandSearches(w.query)
+		if err == nil {
+			err = showQuery(&buf, []string{w.project()}, q)
+		}
 		if w.title == "all" {
 			cachedMilestones(w.project())
 		}
@@ -438,7 +439,7 @@ func (w *awin) load() {
 		if w.title == "all" {
 			var names []string
 			for _, m := range cachedMilestones(w.project()) {
-				names = append(names, getString(m.Title))
+				names = append(names, m.Title)
 			}
 			if len(names) > 0 {
 				w.Fprintf("body", "Milestones: %s\n\n", strings.Join(names, " "))
@@ -491,21 +492,21 @@ func (w *awin) put() {
 	case modeSingle, modeCreate:
 		old := w.github
 		if w.mode == modeCreate {
-			old = new(github.Issue)
+			old = new(schema.Issue)
 		}
 		data, err := w.ReadAll("body")
 		if err != nil {
 			w.Err(fmt.Sprintf("Put: %v", err))
 			return
 		}
-		issue, _, err := writeIssue(w.project(), old, data, false)
+		issue, err := writeIssue(w.project(), old, data, false)
 		if err != nil {
 			w.Err(err.Error())
 			return
 		}
 		if w.mode == modeCreate {
 			w.mode = modeSingle
-			w.id = getInt(issue.Number)
+			w.id = issue.Number
 			w.title = fmt.Sprint(w.id)
 			w.Name(w.prefix + w.title)
 			w.github = issue
@@ -0,0 +1,238 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"rsc.io/github"
+)
+
+// applyColumns lists the columns that “issue apply” changes.
+// Other columns written by -csv, like title, are allowed but ignored,
+// so that the output of -csv can be edited and applied.
+var applyColumns = []string{"number", "labels", "milestone", "assignee", "state", "comment"}
+
+func isCSVFile(arg string) bool {
+	return arg == "-" || strings.HasSuffix(arg, ".csv") || strings.HasSuffix(arg, ".tsv")
+}
+
+// applyIssues implements “issue apply file.csv”,
+// which applies the changes listed in a CSV file, one issue per row.
+// The first row names the columns; see applyColumns.
+// A file named *.tsv is read as tab-separated values,
+// and a file named - is read from standard input.
+func applyIssues(project string, args []string) {
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "usage: issue [-p owner/repo] apply file.csv\n")
+		exit(2)
+	}
+	file := args[0]
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		fatal(err)
+	}
+	cr := csv.NewReader(bytes.NewReader(data))
+	if strings.HasSuffix(file, ".tsv") {
+		cr.Comma = '\t'
+	}
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		fatal(err)
+	}
+	if len(rows) == 0 {
+		fatalf("%s: no header row", file)
+	}
+
+	col := make(map[string]int)
+	for i, name := range rows[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(applyColumns, name) && csvFields[name] == nil {
+			fatalf("%s: unknown column %q (known columns are %s)", file, name, strings.Join(applyColumns, ","))
+		}
+		col[name] = i
+	}
+	if _, ok := col["number"]; !ok {
+		fatalf("%s: no number column", file)
+	}
+
+	updated, failed := 0, 0
+	for i, row := range rows[1:] {
+		get := func(name string) string {
+			if j, ok := col[name]; ok && j < len(row) {
+				return strings.TrimSpace(row[j])
+			}
+			return ""
+		}
+		if repo := get("repo"); repo != "" && repo != project {
+			log.Printf("%s:%d: issue is in %s, not %s", file, i+2, repo, project)
+			failed++
+			continue
+		}
+		n, err := parseNumber(get("number"))
+		if err != nil {
+			log.Printf("%s:%d: %v", file, i+2, err)
+			failed++
+			continue
+		}
+		did, err := applyRow(project, n, get)
+		switch {
+		case err != nil:
+			log.Printf("%s:%d: #%d: %v", file, i+2, n, err)
+			failed++
+		case len(did) == 0:
+			fmt.Printf("#%d\tno changes\n", n)
+		default:
+			fmt.Printf("#%d\t%s\n", n, strings.Join(did, ", "))
+			updated++
+		}
+	}
+	log.Printf("updated %d issue%s", updated, suffix(updated))
+	if failed > 0 {
+		fatalf("failed to update %d issue%s", failed, suffix(failed))
+	}
+}
+
+// applyRow applies the changes in one row of an “issue apply” file to issue n,
+// using get to read the row's columns, and returns a list of what it changed.
+// An empty column means no change, and “none” clears a milestone,
+// assignee, or label list. Labels prefixed by + or - are added or removed;
+// an unprefixed list of labels replaces the issue's labels.
+func applyRow(project string, n int, get func(string) string) (did []string, err error) {
+	issue, err := getIssue(project, n)
+	if err != nil {
+		return nil, err
+	}
+	target := &github.Issue{ID: string(issue.Id), Number: n}
+
+	// Check the whole row before changing anything.
+	var errbuf bytes.Buffer
+	input := make(map[string]any)
+	var addLabels, removeLabels []*github.Label
+	if labels := get("labels"); labels != "" {
+		var add, remove, set []string
+		for _, name := range strings.Fields(labels) {
+			switch {
+			case strings.HasPrefix(name, "+") && len(name) > 1:
+				add = append(add, name[1:])
+			case strings.HasPrefix(name, "-") && len(name) > 1:
+				remove = append(remove, name[1:])
+			default:
+				set = append(set, name)
+			}
+		}
+		if len(set) > 0 && len(add)+len(remove) > 0 {
+			return nil, fmt.Errorf("cannot mix +label or -label with a label list")
+		}
+		if labels == "none" {
+			set = []string{}
+		}
+		if set != nil {
+			if names := diffList(strings.Join(set, " "), "", getLabelNames(issue.Labels)); names != nil {
+				ids := []string{}
+				for _, lab := range findLabels(&errbuf, project, *names) {
+					ids = append(ids, lab.ID)
+				}
+				input["labelIds"] = ids
+			}
+		}
+		have := make(map[string]bool)
+		for _, name := range getLabelNames(issue.Labels) {
+			have[name] = true
+		}
+		add = slices.DeleteFunc(add, func(name string) bool { return have[name] })
+		remove = slices.DeleteFunc(remove, func(name string) bool { return !have[name] })
+		addLabels = findLabels(&errbuf, project, add)
+		removeLabels = findLabels(&errbuf, project, remove)
+	}
+	if name := get("milestone"); name != "" && name != getMilestoneTitle(issue.Milestone) {
+		if name == "none" {
+			if issue.Milestone != nil {
+				input["milestoneId"] = nil
+			}
+		} else if m := findMilestone(&errbuf, project, &name); m != nil {
+			input["milestoneId"] = m.Id
+		}
+	}
+	if logins := get("assignee"); logins != "" {
+		var have []string
+		for _, u := range z(issue.Assignees).Nodes {
+			have = append(have, u.Login)
+		}
+		if logins == "none" {
+			logins = ""
+		}
+		if diffList(logins, "", have) != nil {
+			ids := []string{}
+			for _, login := range strings.Fields(logins) {
+				ids = append(ids, findUsers(&errbuf, strings.TrimPrefix(login, "@"))...)
+			}
+			input["assigneeIds"] = ids
+		}
+	}
+	switch state := strings.ToLower(get("state")); state {
+	default:
+		return nil, fmt.Errorf("invalid state %q (want open or closed)", state)
+	case "":
+		// no change
+	case "open", "closed":
+		if state != getState(issue) {
+			input["state"] = strings.ToUpper(state)
+		}
+	}
+	if errbuf.Len() > 0 {
+		return nil, errors.New(strings.ReplaceAll(strings.TrimSpace(errbuf.String()), "\n", "; "))
+	}
+
+	if comment := get("comment"); comment != "" {
+		if err := client.AddIssueComment(target, comment); err != nil {
+			return did, fmt.Errorf("error saving comment: %v", err)
+		}
+		did = append(did, "commented")
+	}
+	if len(input) > 0 {
+		if err := updateIssue(issue, input); err != nil {
+			return did, fmt.Errorf("error changing metadata: %v", err)
+		}
+		for _, f := range []struct{ key, name string }{
+			{"labelIds", "labels"},
+			{"milestoneId", "milestone"},
+			{"assigneeIds", "assignees"},
+			{"state", "state"},
+		} {
+			if _, ok := input[f.key]; ok {
+				did = append(did, "changed "+f.name)
+			}
+		}
+	}
+	if len(addLabels) > 0 {
+		if err := client.AddIssueLabels(target, addLabels...); err != nil {
+			return did, fmt.Errorf("error adding labels: %v", err)
+		}
+		did = append(did, "added labels")
+	}
+	if len(removeLabels) > 0 {
+		if err := client.RemoveIssueLabels(target, removeLabels...); err != nil {
+			return did, fmt.Errorf("error removing labels: %v", err)
+		}
+		did = append(did, "removed labels")
+	}
+	return did, nil
+}
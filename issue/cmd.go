@@ -0,0 +1,611 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"rsc.io/github"
+	"rsc.io/github/schema"
+)
+
+// A command is a command like “issue comment N”
+// that changes issues directly, without an editor.
+type command struct {
+	name string
+
+	// match reports whether arg, the argument after the command name,
+	// invokes the command, as opposed to being part of a search
+	// that happens to begin with the name, like “issue comment crash”.
+	match func(arg string) bool
+
+	// run runs the command in project with the arguments after the name.
+	run func(project string, args []string)
+}
+
+var commands = []*command{
+	{"new", isFlag, newIssue},
+	{"comment", isNumber, commentIssue},
+	{"close", isNumber, closeIssue},
+	{"reopen", isNumber, reopenIssue},
+	{"label", isNumber, labelIssues},
+	{"milestone", isNumberOrList, milestoneIssues},
+	{"assign", isNumber, assignIssue},
+	{"unassign", isNumber, unassignIssue},
+	{"react", isNumber, reactIssue},
+	{"lock", isNumber, lockIssue},
+	{"unlock", isNumber, unlockIssue},
+	{"transfer", isNumber, transferIssue},
+	{"dup", isNumber, dupIssue},
+	{"download", isNumber, downloadIssue},
+	{"apply", isCSVFile, applyIssues},
+}
+
+// lookupCommand returns the command invoked by args, or nil if there is none.
+func lookupCommand(args []string) *command {
+	if len(args) < 2 {
+		return nil
+	}
+	for _, cmd := range commands {
+		if cmd.name == args[0] && cmd.match(args[1]) {
+			return cmd
+		}
+	}
+	return nil
+}
+
+func isFlag(arg string) bool {
+	return strings.HasPrefix(arg, "-")
+}
+
+func isNumber(arg string) bool {
+	_, err := parseNumber(arg)
+	return err == nil
+}
+
+func isNumberOrList(arg string) bool {
+	return arg == "list" || isNumber(arg)
+}
+
+// parseNumber parses an issue number, like 123 or #123.
+func parseNumber(arg string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid issue number %q", arg)
+	}
+	return n, nil
+}
+
+// newFlagSet returns a new flag set for the named command,
+// using usage as the command's usage message.
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: issue [-p owner/repo] %s\n", usage)
+		fs.PrintDefaults()
+		exit(2)
+	}
+	return fs
+}
+
+// commentIssue implements “issue comment N [-m text]”,
+// which adds a comment to issue N, reading the text
+// from standard input if there is no -m flag.
+func commentIssue(project string, args []string) {
+	fs := newFlagSet("comment", "comment N [-m text]")
+	text := fs.String("m", "", "comment `text` (default standard input)")
+	n, _ := parseNumber(args[0])
+	fs.Parse(args[1:])
+	if fs.NArg() > 0 {
+		fs.Usage()
+	}
+
+	body := *text
+	if body == "" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fatal(err)
+		}
+		body = string(data)
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		fatal("empty comment")
+	}
+
+	commentIfAny(project, n, body)
+	log.Printf("https://%s/%s/issues/%d commented", *hostFlag, project, n)
+}
+
+// closeIssue implements “issue close N [-reason reason] [-m text]”,
+// which closes issue N, after adding the comment text if given.
+func closeIssue(project string, args []string) {
+	fs := newFlagSet("close", "close N [-reason completed|not-planned|duplicate] [-m text]")
+	reason := fs.String("reason", "completed", "`reason` for closing: completed, not-planned, or duplicate")
+	text := fs.String("m", "", "comment `text` to add before closing")
+	n, _ := parseNumber(args[0])
+	fs.Parse(args[1:])
+	if fs.NArg() > 0 {
+		fs.Usage()
+	}
+	var stateReason schema.IssueClosedStateReason
+	switch *reason {
+	default:
+		fs.Usage()
+	case "completed":
+		stateReason = schema.IssueClosedStateReason_COMPLETED
+	case "not-planned":
+		stateReason = schema.IssueClosedStateReason_NOT_PLANNED
+	case "duplicate":
+		// The API schema used by this program has no DUPLICATE reason.
+		log.Printf("closing as not planned: no duplicate close reason in this API version; use “issue dup N M” to mark a duplicate")
+		stateReason = schema.IssueClosedStateReason_NOT_PLANNED
+	}
+
+	issue := commentIfAny(project, n, *text)
+	if err := closeWithReason(issue.ID, stateReason); err != nil {
+		fatalf("error closing issue: %v", err)
+	}
+	log.Printf("https://%s/%s/issues/%d closed", *hostFlag, project, n)
+}
+
+// closeWithReason closes the issue with the given ID, recording
+// stateReason (COMPLETED or NOT_PLANNED) as the reason.
+func closeWithReason(id string, stateReason schema.IssueClosedStateReason) error {
+	graphql := `
+	  mutation($Input: CloseIssueInput!) {
+	    closeIssue(input: $Input) {
+	      clientMutationId
+	    }
+	  }
+	`
+	input := &schema.CloseIssueInput{IssueId: schema.ID(id), StateReason: stateReason}
+	_, err := client.GraphQLMutation(graphql, github.Vars{"Input": input})
+	return err
+}
+
+// reopenIssue implements “issue reopen N [-m text]”,
+// which reopens issue N, after adding the comment text if given.
+func reopenIssue(project string, args []string) {
+	fs := newFlagSet("reopen", "reopen N [-m text]")
+	text := fs.String("m", "", "comment `text` to add before reopening")
+	n, _ := parseNumber(args[0])
+	fs.Parse(args[1:])
+	if fs.NArg() > 0 {
+		fs.Usage()
+	}
+
+	issue := commentIfAny(project, n, *text)
+	if err := client.ReopenIssue(issue); err != nil {
+		fatalf("error reopening issue: %v", err)
+	}
+	log.Printf("https://%s/%s/issues/%d reopened", *hostFlag, project, n)
+}
+
+// commentIfAny returns issue n in project,
+// after adding text as a comment if text is not empty.
+func commentIfAny(project string, n int, text string) *github.Issue {
+	issue, err := getIssue(project, n)
+	if err != nil {
+		fatal(err)
+	}
+	target := &github.Issue{ID: string(issue.Id), Number: n}
+	if text = strings.TrimSpace(text); text != "" {
+		if err := client.AddIssueComment(target, text); err != nil {
+			fatalf("error saving comment: %v", err)
+		}
+	}
+	return target
+}
+
+// labelIssues implements “issue label N... [+label] [-label]...”,
+// which adds each +label to and removes each -label from
+// each of the listed issues.
+func labelIssues(project string, args []string) {
+	usage := func() {
+		fmt.Fprintf(os.Stderr, "usage: issue [-p owner/repo] label N... [+label | -label]...\n")
+		exit(2)
+	}
+	var nums []int
+	var add, remove []string
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "+") && len(arg) > 1:
+			add = append(add, arg[1:])
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			remove = append(remove, arg[1:])
+		case len(add) == 0 && len(remove) == 0 && isNumber(arg):
+			n, _ := parseNumber(arg)
+			nums = append(nums, n)
+		default:
+			usage()
+		}
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		usage()
+	}
+
+	var errbuf bytes.Buffer
+	addLabels := findLabels(&errbuf, project, add)
+	removeLabels := findLabels(&errbuf, project, remove)
+	if errbuf.Len() > 0 {
+		fatal(strings.TrimSpace(errbuf.String()))
+	}
+
+	failed := false
+	for _, n := range nums {
+		issue, err := getIssue(project, n)
+		if err != nil {
+			log.Print(err)
+			failed = true
+			continue
+		}
+		target := &github.Issue{ID: string(issue.Id), Number: n}
+		if len(addLabels) > 0 {
+			if err := client.AddIssueLabels(target, addLabels...); err != nil {
+				log.Printf("#%d: error adding labels: %v", n, err)
+				failed = true
+				continue
+			}
+		}
+		if len(removeLabels) > 0 {
+			if err := client.RemoveIssueLabels(target, removeLabels...); err != nil {
+				log.Printf("#%d: error removing labels: %v", n, err)
+				failed = true
+				continue
+			}
+		}
+		log.Printf("https://%s/%s/issues/%d updated", *hostFlag, project, n)
+	}
+	if failed {
+		exit(1)
+	}
+}
+
+// milestoneIssues implements “issue milestone N... name”,
+// which moves each listed issue to the named milestone
+// (or removes its milestone, if name is “none”),
+// and “issue milestone list”, which lists the open milestones
+// with their due dates and numbers of open issues.
+func milestoneIssues(project string, args []string) {
+	usage := func() {
+		fmt.Fprintf(os.Stderr, "usage: issue [-p owner/repo] milestone N... name\n       issue [-p owner/repo] milestone list\n")
+		exit(2)
+	}
+	if args[0] == "list" {
+		if len(args) > 1 {
+			usage()
+		}
+		milestones, err := loadMilestones(project)
+		if err != nil {
+			fatal(err)
+		}
+		for _, m := range milestones {
+			due := "-"
+			if m.DueOn != "" {
+				due = getTime(m.DueOn).Format("2006-01-02")
+			}
+			fmt.Printf("%s\t%s\t%d\n", due, m.Title, z(m.Issues).TotalCount)
+		}
+		return
+	}
+
+	if len(args) < 2 {
+		usage()
+	}
+	var nums []int
+	for _, arg := range args[:len(args)-1] {
+		n, err := parseNumber(arg)
+		if err != nil {
+			usage()
+		}
+		nums = append(nums, n)
+	}
+	name := args[len(args)-1]
+	input := map[string]any{"milestoneId": nil}
+	if name != "none" {
+		var errbuf bytes.Buffer
+		m := findMilestone(&errbuf, project, &name)
+		if m == nil {
+			fatal(strings.TrimSpace(errbuf.String()))
+		}
+		input["milestoneId"] = m.Id
+	}
+
+	failed := false
+	for _, n := range nums {
+		issue, err := getIssue(project, n)
+		if err == nil {
+			err = updateIssue(issue, input)
+		}
+		if err != nil {
+			log.Printf("#%d: %v", n, err)
+			failed = true
+			continue
+		}
+		log.Printf("https://%s/%s/issues/%d updated", *hostFlag, project, n)
+	}
+	if failed {
+		exit(1)
+	}
+}
+
+// assignIssue implements “issue assign N user...”,
+// which adds the listed users to issue N's assignees.
+func assignIssue(project string, args []string) {
+	changeAssignees(project, "assign", "addAssigneesToAssignable", args)
+}
+
+// unassignIssue implements “issue unassign N user...”,
+// which removes the listed users from issue N's assignees.
+func unassignIssue(project string, args []string) {
+	changeAssignees(project, "unassign", "removeAssigneesFromAssignable", args)
+}
+
+// changeAssignees runs the GraphQL mutation to add or remove
+// the assignees listed in args[1:] for issue args[0].
+func changeAssignees(project, name, mutation string, args []string) {
+	if len(args) < 2 {
+		fmt.Fprintf(os.Stderr, "usage: issue [-p owner/repo] %s N user...\n", name)
+		exit(2)
+	}
+	n, _ := parseNumber(args[0])
+	var errbuf bytes.Buffer
+	ids := []string{}
+	for _, login := range args[1:] {
+		if login != "@me" {
+			login = strings.TrimPrefix(login, "@")
+		}
+		ids = append(ids, findUsers(&errbuf, login)...)
+	}
+	if errbuf.Len() > 0 {
+		fatal(strings.TrimSpace(errbuf.String()))
+	}
+
+	issue, err := getIssue(project, n)
+	if err != nil {
+		fatal(err)
+	}
+	graphql := `
+	  mutation($Issue: ID!, $Users: [ID!]!) {
+	    ` + mutation + `(input: {assignableId: $Issue, assigneeIds: $Users}) {
+	      clientMutationId
+	    }
+	  }
+	`
+	if _, err := client.GraphQLMutation(graphql, github.Vars{"Issue": issue.Id, "Users": ids}); err != nil {
+		fatalf("error changing assignees: %v", err)
+	}
+	log.Printf("https://%s/%s/issues/%d updated", *hostFlag, project, n)
+}
+
+// reactions maps the emoji names accepted by “issue react” to reactions.
+var reactions = map[string]schema.ReactionContent{
+	"+1":         schema.ReactionContent_THUMBS_UP,
+	"thumbsup":   schema.ReactionContent_THUMBS_UP,
+	"-1":         schema.ReactionContent_THUMBS_DOWN,
+	"thumbsdown": schema.ReactionContent_THUMBS_DOWN,
+	"laugh":      schema.ReactionContent_LAUGH,
+	"confused":   schema.ReactionContent_CONFUSED,
+	"heart":      schema.ReactionContent_HEART,
+	"hooray":     schema.ReactionContent_HOORAY,
+	"tada":       schema.ReactionContent_HOORAY,
+	"rocket":     schema.ReactionContent_ROCKET,
+	"eyes":       schema.ReactionContent_EYES,
+}
+
+// reactIssue implements “issue react N [-comment K] [-remove] emoji”,
+// which adds (or removes) a reaction to issue N or to its K'th comment.
+func reactIssue(project string, args []string) {
+	fs := newFlagSet("react", "react N [-comment K] [-remove] emoji")
+	comment := fs.Int("comment", 0, "react to the `K`'th comment instead of the issue")
+	remove := fs.Bool("remove", false, "remove the reaction")
+	n, _ := parseNumber(args[0])
+	fs.Parse(args[1:])
+	if fs.NArg() != 1 || *comment < 0 {
+		fs.Usage()
+	}
+	content, ok := reactions[strings.Trim(fs.Arg(0), ":")]
+	if !ok {
+		fatalf("unknown reaction %s", fs.Arg(0))
+	}
+
+	var subject string
+	if *comment == 0 {
+		issue, err := getIssue(project, n)
+		if err != nil {
+			fatal(err)
+		}
+		subject = string(issue.Id)
+	} else {
+		_, _, timeline, err := readIssue(project, n)
+		if err != nil {
+			fatal(err)
+		}
+		k := 0
+		for _, item := range timeline {
+			if com, ok := item.(*schema.IssueComment); ok {
+				if k++; k == *comment {
+					subject = string(com.Id)
+					break
+				}
+			}
+		}
+		if subject == "" {
+			fatalf("%s#%d has only %d comments", project, n, k)
+		}
+	}
+
+	mutation := "addReaction"
+	if *remove {
+		mutation = "removeReaction"
+	}
+	graphql := `
+	  mutation($Subject: ID!, $Content: ReactionContent!) {
+	    ` + mutation + `(input: {subjectId: $Subject, content: $Content}) {
+	      clientMutationId
+	    }
+	  }
+	`
+	if _, err := client.GraphQLMutation(graphql, github.Vars{"Subject": subject, "Content": content}); err != nil {
+		fatalf("error changing reaction: %v", err)
+	}
+	log.Printf("https://%s/%s/issues/%d updated", *hostFlag, project, n)
+}
+
+// lockIssue implements “issue lock N [-reason reason]”,
+// which locks the conversation on issue N.
+func lockIssue(project string, args []string) {
+	fs := newFlagSet("lock", "lock N [-reason off-topic|too-heated|resolved|spam]")
+	reason := fs.String("reason", "", "`reason` for locking: off-topic, too-heated, resolved, or spam")
+	n, _ := parseNumber(args[0])
+	fs.Parse(args[1:])
+	if fs.NArg() > 0 {
+		fs.Usage()
+	}
+	input := map[string]any{}
+	switch *reason {
+	default:
+		fs.Usage()
+	case "":
+		// no reason
+	case "off-topic", "too-heated", "resolved", "spam":
+		input["lockReason"] = strings.ToUpper(strings.ReplaceAll(*reason, "-", "_"))
+	}
+
+	issue, err := getIssue(project, n)
+	if err != nil {
+		fatal(err)
+	}
+	input["lockableId"] = issue.Id
+	graphql := `
+	  mutation($Input: LockLockableInput!) {
+	    lockLockable(input: $Input) {
+	      clientMutationId
+	    }
+	  }
+	`
+	if _, err := client.GraphQLMutation(graphql, github.Vars{"Input": input}); err != nil {
+		fatalf("error locking issue: %v", err)
+	}
+	log.Printf("https://%s/%s/issues/%d locked", *hostFlag, project, n)
+}
+
+// unlockIssue implements “issue unlock N”,
+// which unlocks the conversation on issue N.
+func unlockIssue(project string, args []string) {
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "usage: issue [-p owner/repo] unlock N\n")
+		exit(2)
+	}
+	n, _ := parseNumber(args[0])
+	issue, err := getIssue(project, n)
+	if err != nil {
+		fatal(err)
+	}
+	graphql := `
+	  mutation($Issue: ID!) {
+	    unlockLockable(input: {lockableId: $Issue}) {
+	      clientMutationId
+	    }
+	  }
+	`
+	if _, err := client.GraphQLMutation(graphql, github.Vars{"Issue": issue.Id}); err != nil {
+		fatalf("error unlocking issue: %v", err)
+	}
+	log.Printf("https://%s/%s/issues/%d unlocked", *hostFlag, project, n)
+}
+
+// transferIssue implements “issue transfer N owner/repo”,
+// which moves issue N to the repository owner/repo
+// and prints its new number and URL.
+func transferIssue(project string, args []string) {
+	if len(args) != 2 || strings.Count(args[1], "/") != 1 {
+		fmt.Fprintf(os.Stderr, "usage: issue [-p owner/repo] transfer N owner/repo\n")
+		exit(2)
+	}
+	n, _ := parseNumber(args[0])
+	issue, err := getIssue(project, n)
+	if err != nil {
+		fatal(err)
+	}
+	repo, err := client.Repo(projectOwner(args[1]), projectRepo(args[1]))
+	if err != nil {
+		fatal(err)
+	}
+	graphql := `
+	  mutation($Issue: ID!, $Repo: ID!) {
+	    transferIssue(input: {issueId: $Issue, repositoryId: $Repo}) {
+	      issue { number url }
+	    }
+	  }
+	`
+	m, err := client.GraphQLMutation(graphql, github.Vars{"Issue": issue.Id, "Repo": repo.ID})
+	if err != nil {
+		fatalf("error transferring issue: %v", err)
+	}
+	if m.TransferIssue == nil || m.TransferIssue.Issue == nil {
+		fatal("transferIssue returned no issue")
+	}
+	fmt.Printf("%d\t%s\n", m.TransferIssue.Issue.Number, m.TransferIssue.Issue.Url)
+}
+
+// dupIssue implements “issue dup N M [-labels]”,
+// which closes issue N as a duplicate of issue M.
+func dupIssue(project string, args []string) {
+	fs := newFlagSet("dup", "dup N M [-labels]")
+	copyLabels := fs.Bool("labels", false, "add N's labels to M")
+	if len(args) < 2 {
+		fs.Usage()
+	}
+	n, _ := parseNumber(args[0])
+	m, err := parseNumber(args[1])
+	if err != nil || m == n {
+		fs.Usage()
+	}
+	fs.Parse(args[2:])
+	if fs.NArg() > 0 {
+		fs.Usage()
+	}
+
+	issue, err := getIssue(project, n)
+	if err != nil {
+		fatal(err)
+	}
+	orig, err := getIssue(project, m)
+	if err != nil {
+		fatal(err)
+	}
+	target := &github.Issue{ID: string(issue.Id), Number: n}
+	if err := client.AddIssueComment(target, fmt.Sprintf("Duplicate of #%d", m)); err != nil {
+		fatalf("error saving comment: %v", err)
+	}
+	// GitHub marks the issue as a duplicate because of the comment.
+	// The API schema used by this program has no DUPLICATE close reason.
+	if err := closeWithReason(target.ID, schema.IssueClosedStateReason_NOT_PLANNED); err != nil {
+		fatalf("error closing issue: %v", err)
+	}
+	log.Printf("https://%s/%s/issues/%d closed", *hostFlag, project, n)
+
+	if *copyLabels {
+		var labels []*github.Label
+		for _, lab := range z(issue.Labels).Nodes {
+			labels = append(labels, &github.Label{ID: string(lab.Id), Name: lab.Name})
+		}
+		if len(labels) > 0 {
+			if err := client.AddIssueLabels(&github.Issue{ID: string(orig.Id), Number: m}, labels...); err != nil {
+				fatalf("error adding labels: %v", err)
+			}
+			log.Printf("https://%s/%s/issues/%d updated", *hostFlag, project, m)
+		}
+	}
+}
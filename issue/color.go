@@ -0,0 +1,104 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"rsc.io/github/schema"
+)
+
+// useColor reports whether to color the output,
+// according to the -color flag.
+var useColor bool
+
+// setColor sets useColor according to mode, the -color flag.
+// In auto mode, issue colors its output when standard output
+// is a terminal and $NO_COLOR is not set.
+func setColor(mode string) error {
+	switch mode {
+	default:
+		return fmt.Errorf("invalid -color %q: must be auto, always, or never", mode)
+	case "always":
+		useColor = true
+	case "never":
+		useColor = false
+	case "auto":
+		fi, err := os.Stdout.Stat()
+		useColor = err == nil && fi.Mode()&os.ModeCharDevice != 0 &&
+			os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+	}
+	return nil
+}
+
+// colorize returns s wrapped in the ANSI escape sequences
+// to display it with the given SGR parameters, like "32" for green,
+// or else s unchanged if colors are disabled.
+func colorize(s, sgr string) string {
+	if !useColor || s == "" {
+		return s
+	}
+	return "\x1b[" + sgr + "m" + s + "\x1b[0m"
+}
+
+// colorState returns the issue state s, colored like GitHub does:
+// green for open, red for closed, and purple for merged.
+func colorState(s string) string {
+	return colorize(s, stateSGR(s))
+}
+
+// stateSGR returns the SGR parameters for displaying the issue state s.
+func stateSGR(s string) string {
+	switch s {
+	case "open":
+		return "32"
+	case "closed":
+		return "31"
+	case "merged":
+		return "35"
+	}
+	return "0"
+}
+
+// colorMilestone returns the milestone title s, colored.
+func colorMilestone(s string) string {
+	return colorize(s, "36")
+}
+
+// colorLabels returns the names of the labels in x, sorted and joined by spaces,
+// with each name shown in the label's color.
+func colorLabels(x *schema.LabelConnection) string {
+	if !useColor {
+		return strings.Join(getLabelNames(x), " ")
+	}
+	nodes := slices.Clone(z(x).Nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	var labels []string
+	for _, lab := range nodes {
+		labels = append(labels, colorLabel(lab.Name, lab.Color))
+	}
+	return strings.Join(labels, " ")
+}
+
+// colorLabel returns name displayed on a background of the given color,
+// a hexadecimal RGB color like "d73a4a", using black or white text,
+// whichever is more legible.
+func colorLabel(name, color string) string {
+	rgb, err := strconv.ParseUint(color, 16, 32)
+	if err != nil || len(color) != 6 {
+		return name
+	}
+	r, g, b := rgb>>16, rgb>>8&0xff, rgb&0xff
+	fg := "30"
+	if r*299+g*587+b*114 < 128*1000 {
+		fg = "97"
+	}
+	return colorize(" "+name+" ", fmt.Sprintf("%s;48;2;%d;%d;%d", fg, r, g, b))
+}
@@ -0,0 +1,158 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"rsc.io/github/schema"
+)
+
+// downloadIssue implements “issue download N [-d dir]”,
+// which downloads the files and images attached to issue N
+// and its comments into dir, along with a copy of the issue text,
+// issue.txt, in which references to the attachments are rewritten
+// to refer to the downloaded files.
+func downloadIssue(project string, args []string) {
+	fs := newFlagSet("download", "download N [-d dir]")
+	dirFlag := fs.String("d", "", "download into `dir` (default issue-N)")
+	n, _ := parseNumber(args[0])
+	fs.Parse(args[1:])
+	if fs.NArg() > 0 {
+		fs.Usage()
+	}
+	dir := *dirFlag
+	if dir == "" {
+		dir = fmt.Sprintf("issue-%d", n)
+	}
+
+	issue, pr, timeline, err := readIssue(project, n)
+	if err != nil {
+		fatal(err)
+	}
+	bodies := []string{issue.Body}
+	for _, item := range timeline {
+		switch ev := item.(type) {
+		case *schema.IssueComment:
+			bodies = append(bodies, ev.Body)
+		case *schema.PullRequestReview:
+			bodies = append(bodies, ev.Body)
+		}
+	}
+	urls := attachmentURLs(bodies)
+	if len(urls) == 0 {
+		log.Printf("https://%s/%s/issues/%d has no attachments", *hostFlag, project, n)
+		return
+	}
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		fatal(err)
+	}
+
+	failed := false
+	used := make(map[string]bool)
+	local := make(map[string]string)
+	for _, url := range urls {
+		name, err := downloadAttachment(dir, url, used)
+		if err != nil {
+			log.Print(err)
+			failed = true
+			continue
+		}
+		local[url] = name
+		fmt.Printf("%s\n", filepath.Join(dir, name))
+	}
+
+	// Write the issue text with the attachment references rewritten.
+	// Colors would only clutter the file.
+	useColor = false
+	var buf bytes.Buffer
+	if err := printIssue(&buf, project, issue, pr, timeline); err != nil {
+		fatal(err)
+	}
+	text := buf.String()
+	for url, name := range local {
+		text = strings.ReplaceAll(text, url, name)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "issue.txt"), []byte(text), 0666); err != nil {
+		fatal(err)
+	}
+	if failed {
+		exit(1)
+	}
+}
+
+// attachmentURLs returns the URLs of the files and images
+// uploaded to GitHub and referenced in bodies, in order of first appearance.
+func attachmentURLs(bodies []string) []string {
+	host := regexp.QuoteMeta(*hostFlag)
+	re := regexp.MustCompile(`https://(?:` + host + `/(?:user-attachments/(?:assets|files)/|[\w.-]+/[\w.-]+/(?:files|assets)/)|(?:private-)?user-images\.githubusercontent\.com/)[^\s()<>"'\]]+`)
+	var urls []string
+	seen := make(map[string]bool)
+	for _, body := range bodies {
+		for _, url := range re.FindAllString(body, -1) {
+			// Punctuation after a bare URL ends the sentence, not the URL.
+			url = strings.TrimRight(url, ".,;:")
+			if !seen[url] {
+				seen[url] = true
+				urls = append(urls, url)
+			}
+		}
+	}
+	return urls
+}
+
+// downloadAttachment downloads url into dir and returns the name of the new file.
+// The name is the attachment's original file name if known,
+// or else the last element of the URL, adjusted as needed
+// to be different from the names recorded in used.
+func downloadAttachment(dir, url string, used map[string]bool) (string, error) {
+	resp, err := client.HTTPClient().Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("%s: %s", url, resp.Status)
+	}
+
+	name := path.Base(strings.TrimSuffix(url, "/"))
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil && params["filename"] != "" {
+		name = filepath.Base(params["filename"])
+	}
+	if path.Ext(name) == "" {
+		ctype, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+		if exts, _ := mime.ExtensionsByType(ctype); len(exts) > 0 {
+			name += exts[0]
+		}
+	}
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 2; used[name] || name == "issue.txt"; i++ {
+		name = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+	used[name] = true
+
+	f, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		return "", fmt.Errorf("%s: %v", url, err)
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return name, nil
+}
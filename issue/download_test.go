@@ -0,0 +1,30 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAttachmentURLs(t *testing.T) {
+	bodies := []string{
+		"See https://github.com/user-attachments/assets/1234-abcd.\n" +
+			"Crash log: https://github.com/user-attachments/files/42/crash.txt, and again:\n" +
+			"![image](https://github.com/user-attachments/assets/1234-abcd)",
+		"Old image: <img src=\"https://user-images.githubusercontent.com/1/2.png\">;\n" +
+			"Repo file: https://github.com/golang/go/files/99/trace.zip: see above\n" +
+			"Not an attachment: https://github.com/golang/go/issues/1.",
+	}
+	want := []string{
+		"https://github.com/user-attachments/assets/1234-abcd",
+		"https://github.com/user-attachments/files/42/crash.txt",
+		"https://user-images.githubusercontent.com/1/2.png",
+		"https://github.com/golang/go/files/99/trace.zip",
+	}
+	if got := attachmentURLs(bodies); !slices.Equal(got, want) {
+		t.Errorf("attachmentURLs:\nhave %q\nwant %q", got, want)
+	}
+}
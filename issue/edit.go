@@ -6,7 +6,6 @@ package main
 
 import (
 	"bytes"
-	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -14,44 +13,204 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"regexp"
 	"strconv"
 	"strings"
-	"time"
+	"sync"
+	"unicode/utf8"
 
-	"github.com/google/go-github/v62/github"
+	"rsc.io/github"
+	"rsc.io/github/schema"
 )
 
-func editIssue(project string, original []byte, issue *github.Issue) {
+func editIssue(project string, original []byte, issue *schema.Issue) {
 	updated := editText(original)
 	if bytes.Equal(original, updated) {
 		log.Print("no changes made")
 		return
 	}
 
-	newIssue, _, err := writeIssue(project, issue, updated, false)
+	// Check the editable blocks before changing anything,
+	// so that a damaged marker line does not leave a partial update.
+	edits, err := checkEditable(issue, original, updated)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
+	}
+	newIssue, err := writeIssue(project, issue, updated, false)
+	if err != nil {
+		fatal(err)
 	}
 	if newIssue != nil {
 		issue = newIssue
 	}
-	log.Printf("https://github.com/%s/issues/%d updated", project, getInt(issue.Number))
+	if err := writeEditable(issue, edits); err != nil {
+		fatal(err)
+	}
+	log.Printf("https://%s/%s/issues/%d updated", *hostFlag, project, issue.Number)
+}
+
+// editableText reports whether printIssue should print the issue body,
+// if the authenticated user can update it, and the user's own comments
+// as editable blocks, for editing with writeEditable.
+var editableText bool
+
+// printEditable prints text, the body of the issue or comment with the given ID,
+// as an editable block: the unprocessed text, delimited by
+// begin and end marker lines naming the ID.
+func printEditable(w io.Writer, id, text string) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	fmt.Fprintf(w, "\n<!-- begin %s: edit text below to change it -->\n%s\n<!-- end %s -->\n", id, text, id)
+}
+
+var editableRE = regexp.MustCompile(`(?ms)^<!-- begin (\S+): edit text below to change it -->\n(.*?)^<!-- end (\S+) -->$`)
+
+// editableBlocks returns the text of the editable blocks in text,
+// keyed by ID. Editors on Windows may have written \r\n line endings,
+// which are converted to \n.
+func editableBlocks(text []byte) map[string]string {
+	text = bytes.ReplaceAll(text, []byte("\r\n"), []byte("\n"))
+	blocks := make(map[string]string)
+	for _, m := range editableRE.FindAllSubmatch(text, -1) {
+		if string(m[1]) == string(m[3]) {
+			blocks[string(m[1])] = strings.TrimSpace(string(m[2]))
+		}
+	}
+	return blocks
+}
+
+// checkEditable returns the changed editable blocks
+// in the updated text for issue, compared to the original text,
+// keyed by ID. A block that is missing from updated, most likely because
+// one of its marker lines was changed by mistake, is an error,
+// as is a comment whose text was deleted.
+func checkEditable(issue *schema.Issue, original, updated []byte) (map[string]string, error) {
+	var errbuf bytes.Buffer
+	edits := make(map[string]string)
+	old, changed := editableBlocks(original), editableBlocks(updated)
+	for id, text := range old {
+		newText, ok := changed[id]
+		if !ok {
+			fmt.Fprintf(&errbuf, "cannot find edited text %s; check its begin and end lines\n", id)
+			continue
+		}
+		if newText == text {
+			continue
+		}
+		if newText == "" && id != string(issue.Id) {
+			fmt.Fprintf(&errbuf, "cannot save empty comment %s\n", id)
+			continue
+		}
+		edits[id] = newText
+	}
+	if errbuf.Len() > 0 {
+		return nil, errors.New(strings.TrimSpace(errbuf.String()))
+	}
+	return edits, nil
+}
+
+// writeEditable saves the edited blocks returned by checkEditable,
+// which are the issue description or comments on issue.
+func writeEditable(issue *schema.Issue, edits map[string]string) error {
+	graphql := `
+	  mutation($Input: UpdateIssueCommentInput!) {
+	    updateIssueComment(input: $Input) {
+	      clientMutationId
+	    }
+	  }
+	`
+	var errbuf bytes.Buffer
+	for id, text := range edits {
+		if id == string(issue.Id) {
+			if err := updateIssue(issue, map[string]any{"body": text}); err != nil {
+				fmt.Fprintf(&errbuf, "error saving edited description: %v\n", err)
+			}
+			continue
+		}
+		input := map[string]any{"id": id, "body": text}
+		if _, err := client.GraphQLMutation(graphql, github.Vars{"Input": input}); err != nil {
+			fmt.Fprintf(&errbuf, "error saving edited comment: %v\n", err)
+		}
+	}
+	if errbuf.Len() > 0 {
+		return errors.New(strings.TrimSpace(errbuf.String()))
+	}
+	return nil
+}
+
+// newIssue creates a new issue in project using the flags in args,
+// as in “issue new -title T -body B”, and prints its number and URL.
+func newIssue(project string, args []string) {
+	fs := newFlagSet("new", "new -title title [-body text | -body-file file] [-label labels] [-milestone name] [-assignee login]")
+	title := fs.String("title", "", "issue `title`")
+	body := fs.String("body", "", "issue `text`")
+	bodyFile := fs.String("body-file", "", "read issue text from `file` (- for standard input)")
+	labels := fs.String("label", "", "comma-separated `labels`")
+	milestone := fs.String("milestone", "", "`milestone` name")
+	assignee := fs.String("assignee", "", "assignee `login`")
+	fs.Parse(args)
+	if *title == "" || fs.NArg() > 0 || *body != "" && *bodyFile != "" {
+		fs.Usage()
+	}
+
+	text := *body
+	if *bodyFile != "" {
+		var data []byte
+		var err error
+		if *bodyFile == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(*bodyFile)
+		}
+		if err != nil {
+			fatal(err)
+		}
+		text = string(data)
+	}
+
+	var errbuf bytes.Buffer
+	input := map[string]any{"title": *title} // CreateIssueInput fields to set
+	if *labels != "" {
+		ids := []string{}
+		for _, lab := range findLabels(&errbuf, project, strings.Split(*labels, ",")) {
+			ids = append(ids, lab.ID)
+		}
+		input["labelIds"] = ids
+	}
+	if *milestone != "" {
+		if m := findMilestone(&errbuf, project, milestone); m != nil {
+			input["milestoneId"] = m.Id
+		}
+	}
+	if *assignee != "" {
+		if ids := findUsers(&errbuf, *assignee); ids != nil {
+			input["assigneeIds"] = ids
+		}
+	}
+	if errbuf.Len() > 0 {
+		fatal(strings.TrimSpace(errbuf.String()))
+	}
+
+	issue, err := createIssue(project, input, strings.TrimSpace(text))
+	if err != nil {
+		fatalf("error creating issue: %v", err)
+	}
+	fmt.Printf("%d\t%s\n", issue.Number, issue.Url)
 }
 
 func editText(original []byte) []byte {
 	f, err := ioutil.TempFile("", "issue-edit-")
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	if err := ioutil.WriteFile(f.Name(), original, 0600); err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	if err := runEditor(f.Name()); err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	updated, err := ioutil.ReadFile(f.Name())
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	name := f.Name()
 	f.Close()
@@ -91,7 +250,7 @@ func runEditor(filename string) error {
 
 const bulkHeader = "\nBulk editing these issues:"
 
-func writeIssue(project string, old *github.Issue, updated []byte, isBulk bool) (issue *github.Issue, rate *github.Rate, err error) {
+func writeIssue(project string, old *schema.Issue, updated []byte, isBulk bool) (issue *schema.Issue, err error) {
 	var errbuf bytes.Buffer
 	defer func() {
 		if errbuf.Len() > 0 {
@@ -101,8 +260,8 @@ func writeIssue(project string, old *github.Issue, updated []byte, isBulk bool)
 
 	sdata := string(updated)
 	off := 0
-	var edit github.IssueRequest
-	var addLabels, removeLabels []string
+	edit := make(map[string]any) // UpdateIssueInput or CreateIssueInput fields to set
+	var addLabels, removeLabels []*github.Label
 	for _, line := range strings.SplitAfter(sdata, "\n") {
 		off += len(line)
 		line = strings.TrimSpace(line)
@@ -114,26 +273,42 @@ func writeIssue(project string, old *github.Issue, updated []byte, isBulk bool)
 			continue
 
 		case strings.HasPrefix(line, "Title:"):
-			edit.Title = diff(line, "Title:", getString(old.Title))
+			if title := diff(line, "Title:", old.Title); title != nil {
+				edit["title"] = *title
+			}
 
 		case strings.HasPrefix(line, "State:"):
-			edit.State = diff(line, "State:", getString(old.State))
+			if state := diff(line, "State:", getState(old)); state != nil {
+				edit["state"] = strings.ToUpper(*state)
+			}
 
 		case strings.HasPrefix(line, "Assignee:"):
-			edit.Assignee = diff(line, "Assignee:", getUserLogin(old.Assignee))
+			if login := diff(line, "Assignee:", getAssignee(old.Assignees)); login != nil {
+				if ids := findUsers(&errbuf, *login); ids != nil {
+					edit["assigneeIds"] = ids
+				}
+			}
 
 		case strings.HasPrefix(line, "Closed:"):
 			continue
 
 		case strings.HasPrefix(line, "Labels:"):
 			if isBulk {
-				addLabels, removeLabels = diffList2(line, "Labels:", getLabelNames(old.Labels))
-			} else {
-				edit.Labels = diffList(line, "Labels:", getLabelNames(old.Labels))
+				added, removed := diffList2(line, "Labels:", getLabelNames(old.Labels))
+				addLabels = findLabels(&errbuf, project, added)
+				removeLabels = findLabels(&errbuf, project, removed)
+			} else if names := diffList(line, "Labels:", getLabelNames(old.Labels)); names != nil {
+				ids := []string{}
+				for _, lab := range findLabels(&errbuf, project, *names) {
+					ids = append(ids, lab.ID)
+				}
+				edit["labelIds"] = ids
 			}
 
 		case strings.HasPrefix(line, "Milestone:"):
-			edit.Milestone = findMilestone(&errbuf, project, diff(line, "Milestone:", getMilestoneTitle(old.Milestone)))
+			if m := findMilestone(&errbuf, project, diff(line, "Milestone:", getMilestoneTitle(old.Milestone))); m != nil {
+				edit["milestoneId"] = m.Id
+			}
 
 		case strings.HasPrefix(line, "URL:"):
 			continue
@@ -141,32 +316,37 @@ func writeIssue(project string, old *github.Issue, updated []byte, isBulk bool)
 		case strings.HasPrefix(line, "Reactions:"):
 			continue
 
+		case strings.HasPrefix(line, "Project:"):
+			continue
+
+		case strings.HasPrefix(line, "Review:"),
+			strings.HasPrefix(line, "Reviewers:"),
+			strings.HasPrefix(line, "Checks:"),
+			strings.HasPrefix(line, "Files:"):
+			// Pull request details.
+			continue
+
 		default:
 			fmt.Fprintf(&errbuf, "unknown summary line: %s\n", line)
 		}
 	}
 
 	if errbuf.Len() > 0 {
-		return nil, nil, nil
+		return nil, nil
 	}
 
-	if getInt(old.Number) == 0 {
-		comment := strings.TrimSpace(sdata[off:])
-		edit.Body = &comment
-		issue, resp, err := client.Issues.Create(context.TODO(), projectOwner(project), projectRepo(project), &edit)
-		if resp != nil {
-			rate = &resp.Rate
-		}
+	if old.Number == 0 {
+		issue, err := createIssue(project, edit, strings.TrimSpace(sdata[off:]))
 		if err != nil {
 			fmt.Fprintf(&errbuf, "error creating issue: %v\n", err)
-			return nil, rate, nil
+			return nil, nil
 		}
-		return issue, rate, nil
+		return issue, nil
 	}
 
-	if getInt(old.Number) == -1 {
+	if old.Number == -1 {
 		// Asking to just sanity check the text parsing.
-		return nil, nil, nil
+		return nil, nil
 	}
 
 	marker := "\nReported by "
@@ -182,16 +362,11 @@ func writeIssue(project string, old *github.Issue, updated []byte, isBulk bool)
 		comment = ""
 	}
 
+	target := &github.Issue{ID: string(old.Id), Number: old.Number}
 	var failed bool
 	var did []string
 	if comment != "" {
-		_, resp, err := client.Issues.CreateComment(context.TODO(), projectOwner(project), projectRepo(project), getInt(old.Number), &github.IssueComment{
-			Body: &comment,
-		})
-		if resp != nil {
-			rate = &resp.Rate
-		}
-		if err != nil {
+		if err := client.AddIssueComment(target, comment); err != nil {
 			fmt.Fprintf(&errbuf, "error saving comment: %v\n", err)
 			failed = true
 		} else {
@@ -199,12 +374,8 @@ func writeIssue(project string, old *github.Issue, updated []byte, isBulk bool)
 		}
 	}
 
-	if edit.Title != nil || edit.State != nil || edit.Assignee != nil || edit.Labels != nil || edit.Milestone != nil {
-		_, resp, err := client.Issues.Edit(context.TODO(), projectOwner(project), projectRepo(project), getInt(old.Number), &edit)
-		if resp != nil {
-			rate = &resp.Rate
-		}
-		if err != nil {
+	if len(edit) > 0 {
+		if err := updateIssue(old, edit); err != nil {
 			fmt.Fprintf(&errbuf, "error changing metadata: %v\n", err)
 			failed = true
 		} else {
@@ -212,16 +383,12 @@ func writeIssue(project string, old *github.Issue, updated []byte, isBulk bool)
 		}
 	}
 	if len(addLabels) > 0 {
-		_, resp, err := client.Issues.AddLabelsToIssue(context.TODO(), projectOwner(project), projectRepo(project), getInt(old.Number), addLabels)
-		if resp != nil {
-			rate = &resp.Rate
-		}
-		if err != nil {
+		if err := client.AddIssueLabels(target, addLabels...); err != nil {
 			fmt.Fprintf(&errbuf, "error adding labels: %v\n", err)
 			failed = true
 		} else {
 			if len(addLabels) == 1 {
-				did = append(did, "added label "+addLabels[0])
+				did = append(did, "added label "+addLabels[0].Name)
 			} else {
 				did = append(did, "added labels")
 			}
@@ -229,15 +396,11 @@ func writeIssue(project string, old *github.Issue, updated []byte, isBulk bool)
 	}
 	if len(removeLabels) > 0 {
 		for _, label := range removeLabels {
-			resp, err := client.Issues.RemoveLabelForIssue(context.TODO(), projectOwner(project), projectRepo(project), getInt(old.Number), label)
-			if resp != nil {
-				rate = &resp.Rate
-			}
-			if err != nil {
-				fmt.Fprintf(&errbuf, "error removing label %s: %v\n", label, err)
+			if err := client.RemoveIssueLabels(target, label); err != nil {
+				fmt.Fprintf(&errbuf, "error removing label %s: %v\n", label.Name, err)
 				failed = true
 			} else {
-				did = append(did, "removed label "+label)
+				did = append(did, "removed label "+label.Name)
 			}
 		}
 	}
@@ -261,6 +424,63 @@ func writeIssue(project string, old *github.Issue, updated []byte, isBulk bool)
 	return
 }
 
+// createIssue creates a new issue in project with the given body
+// and the title and other metadata in input, a partial CreateIssueInput.
+func createIssue(project string, input map[string]any, body string) (*schema.Issue, error) {
+	repo, err := client.Repo(projectOwner(project), projectRepo(project))
+	if err != nil {
+		return nil, err
+	}
+	graphql := `
+	  mutation($Input: CreateIssueInput!) {
+	    createIssue(input: $Input) {
+	      issue {
+	        ` + issueFields + `
+	      }
+	    }
+	  }
+	`
+	input["repositoryId"] = repo.ID
+	input["body"] = body
+	m, err := client.GraphQLMutation(graphql, github.Vars{"Input": input})
+	if err != nil {
+		return nil, err
+	}
+	if m.CreateIssue == nil || m.CreateIssue.Issue == nil {
+		return nil, fmt.Errorf("createIssue returned no issue")
+	}
+	return m.CreateIssue.Issue, nil
+}
+
+// updateIssue applies the changes in input, a partial UpdateIssueInput, to issue.
+// Fields missing from input are left unchanged.
+// If issue is a pull request, updateIssue uses the equivalent UpdatePullRequestInput.
+func updateIssue(issue *schema.Issue, input map[string]any) error {
+	if isPullRequest(issue) {
+		graphql := `
+		  mutation($Input: UpdatePullRequestInput!) {
+		    updatePullRequest(input: $Input) {
+		      clientMutationId
+		    }
+		  }
+		`
+		input["pullRequestId"] = issue.Id
+		_, err := client.GraphQLMutation(graphql, github.Vars{"Input": input})
+		return err
+	}
+
+	graphql := `
+	  mutation($Input: UpdateIssueInput!) {
+	    updateIssue(input: $Input) {
+	      clientMutationId
+	    }
+	  }
+	`
+	input["id"] = issue.Id
+	_, err := client.GraphQLMutation(graphql, github.Vars{"Input": input})
+	return err
+}
+
 func diffList(line, field string, old []string) *[]string {
 	line = strings.TrimSpace(strings.TrimPrefix(line, field))
 	had := make(map[string]bool)
@@ -309,7 +529,7 @@ func diffList2(line, field string, old []string) (added, removed []string) {
 	return
 }
 
-func findMilestone(w io.Writer, project string, name *string) *int {
+func findMilestone(w io.Writer, project string, name *string) *schema.Milestone {
 	if name == nil {
 		return nil
 	}
@@ -321,8 +541,8 @@ func findMilestone(w io.Writer, project string, name *string) *int {
 	}
 
 	for _, m := range all {
-		if getString(m.Title) == *name {
-			return m.Number
+		if m.Title == *name {
+			return m
 		}
 	}
 
@@ -330,6 +550,77 @@ func findMilestone(w io.Writer, project string, name *string) *int {
 	return nil
 }
 
+var labelCache struct {
+	sync.Mutex
+	m map[string][]*github.Label
+}
+
+// findLabels returns the labels in project with the given names,
+// printing a message to w for each name that is not a label.
+func findLabels(w io.Writer, project string, names []string) []*github.Label {
+	if len(names) == 0 {
+		return nil
+	}
+
+	labelCache.Lock()
+	defer labelCache.Unlock()
+	if labelCache.m == nil {
+		labelCache.m = make(map[string][]*github.Label)
+	}
+	all, ok := labelCache.m[project]
+	if !ok {
+		var err error
+		all, err = client.SearchLabels(projectOwner(project), projectRepo(project), "")
+		if err != nil {
+			fmt.Fprintf(w, "Error loading label list: %v\n\tIgnoring label change.\n", err)
+			return nil
+		}
+		labelCache.m[project] = all
+	}
+
+	var out []*github.Label
+Names:
+	for _, name := range names {
+		for _, lab := range all {
+			if lab.Name == name {
+				out = append(out, lab)
+				continue Names
+			}
+		}
+		fmt.Fprintf(w, "Unknown label: %s\n", name)
+	}
+	return out
+}
+
+// findUsers returns the IDs of the users to assign for the Assignee line login:
+// an empty list if login is empty, or else the ID of the named user,
+// where @me names the authenticated user.
+// If there is no such user, findUsers prints a message to w and returns nil.
+func findUsers(w io.Writer, login string) []string {
+	if login == "" {
+		return []string{}
+	}
+	if login == "@me" {
+		q, err := client.GraphQLQuery(`query { viewer { id } }`, nil)
+		if err != nil || q.Viewer == nil {
+			fmt.Fprintf(w, "Cannot find authenticated user: %v\n", err)
+			return nil
+		}
+		return []string{string(q.Viewer.Id)}
+	}
+	graphql := `
+	  query($Login: String!) {
+	    user(login: $Login) { id }
+	  }
+	`
+	q, err := client.GraphQLQuery(graphql, github.Vars{"Login": login})
+	if err != nil || q.User == nil {
+		fmt.Fprintf(w, "Unknown assignee: %s\n", login)
+		return nil
+	}
+	return []string{string(q.User.Id)}
+}
+
 func readBulkIDs(text []byte) []int {
 	var ids []int
 	for _, line := range strings.Split(string(text), "\n") {
@@ -348,7 +639,7 @@ func readBulkIDs(text []byte) []int {
 	return ids
 }
 
-func bulkEditStartFromText(project string, content []byte) (base *github.Issue, original []byte, err error) {
+func bulkEditStartFromText(project string, content []byte) (base *schema.Issue, original []byte, err error) {
 	ids := readBulkIDs(content)
 	if len(ids) == 0 {
 		return nil, nil, fmt.Errorf("found no issues in selection")
@@ -368,39 +659,175 @@ func suffix(n int) string {
 	return "s"
 }
 
-func bulkEditIssues(project string, issues []*github.Issue) {
+func bulkEditIssues(project string, issues []*schema.Issue) {
 	base, original := bulkEditStart(issues)
 	updated := editText(original)
 	if bytes.Equal(original, updated) {
 		log.Print("no changes made")
 		return
 	}
+	if i := bytes.Index(updated, []byte(bulkHeader)); i >= 0 {
+		// Check the text before previewing it.
+		x := *base
+		x.Number = -1
+		if _, err := writeIssue(project, &x, updated, true); err != nil {
+			fatal(err)
+		}
+		issues, err := bulkReadIssuesCached(project, readBulkIDs(updated[i:]))
+		if err != nil {
+			fatal(err)
+		}
+		if bulkPreview(os.Stderr, base, updated, issues) == 0 {
+			log.Print("no changes to make")
+			return
+		}
+		if !*yesFlag && !confirm(fmt.Sprintf("apply changes to %d issue%s?", len(issues), suffix(len(issues)))) {
+			fatal("bulk edit canceled")
+		}
+	}
 	ids, err := bulkWriteIssue(project, base, updated, func(s string) { log.Print(s) })
 	if err != nil {
 		errText := strings.Replace(err.Error(), "\n", "\t\n", -1)
 		if len(ids) > 0 {
-			log.Fatalf("updated %d issue%s with errors:\n\t%v", len(ids), suffix(len(ids)), errText)
+			fatalf("updated %d issue%s with errors:\n\t%v", len(ids), suffix(len(ids)), errText)
 		}
-		log.Fatal(errText)
+		fatal(errText)
 	}
 	log.Printf("updated %d issue%s", len(ids), suffix(len(ids)))
 }
 
-func bulkEditStart(issues []*github.Issue) (*github.Issue, []byte) {
-	common := new(github.Issue)
+// bulkPreview prints to w the changes that the bulk edit text updated,
+// starting from the common issue base, would make to each of issues,
+// and returns the number of issues that would change.
+// Like writeIssue, it only considers the labels that the edit adds or removes,
+// leaving an issue's other labels alone.
+func bulkPreview(w io.Writer, base *schema.Issue, updated []byte, issues []*schema.Issue) int {
+	sdata := string(updated)
+	var state, assignee, milestone *string
+	var addLabels, removeLabels []string
+	off := 0
+	for _, line := range strings.SplitAfter(sdata, "\n") {
+		off += len(line)
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		switch {
+		case strings.HasPrefix(line, "State:"):
+			state = diff(line, "State:", getState(base))
+		case strings.HasPrefix(line, "Assignee:"):
+			assignee = diff(line, "Assignee:", getAssignee(base.Assignees))
+		case strings.HasPrefix(line, "Labels:"):
+			addLabels, removeLabels = diffList2(line, "Labels:", getLabelNames(base.Labels))
+		case strings.HasPrefix(line, "Milestone:"):
+			milestone = diff(line, "Milestone:", getMilestoneTitle(base.Milestone))
+		}
+	}
+	comment := ""
+	if i := strings.Index(sdata, bulkHeader); i >= off {
+		comment = strings.TrimSpace(sdata[off:i])
+	}
+	if comment == "<optional comment here>" {
+		comment = ""
+	}
+
+	changed := 0
+	for _, issue := range issues {
+		if issue == nil {
+			continue
+		}
+		var changes []string
+		change := func(field, old string, new *string) {
+			if new != nil && *new != old {
+				if old == "" {
+					old = "(none)"
+				}
+				to := *new
+				if to == "" {
+					to = "(none)"
+				}
+				changes = append(changes, fmt.Sprintf("%s: %s → %s", field, old, to))
+			}
+		}
+		change("State", getState(issue), state)
+		change("Assignee", getAssignee(issue.Assignees), assignee)
+		change("Milestone", getMilestoneTitle(issue.Milestone), milestone)
+		have := make(map[string]bool)
+		for _, name := range getLabelNames(issue.Labels) {
+			have[name] = true
+		}
+		var labels []string
+		for _, name := range addLabels {
+			if !have[name] {
+				labels = append(labels, "+"+name)
+			}
+		}
+		for _, name := range removeLabels {
+			if have[name] {
+				labels = append(labels, "-"+name)
+			}
+		}
+		if len(labels) > 0 {
+			changes = append(changes, "Labels: "+strings.Join(labels, " "))
+		}
+		if comment != "" {
+			changes = append(changes, "Comment: "+truncateLine(comment, 50))
+		}
+
+		fmt.Fprintf(w, "#%d\t%s\n", issue.Number, issue.Title)
+		if len(changes) == 0 {
+			fmt.Fprintf(w, "\t(no changes)\n")
+			continue
+		}
+		changed++
+		for _, c := range changes {
+			fmt.Fprintf(w, "\t%s\n", c)
+		}
+	}
+	return changed
+}
+
+// truncateLine returns the first line of s,
+// shortened to at most n bytes and marked with ... if it was shortened.
+func truncateLine(s string, n int) string {
+	line, _, more := strings.Cut(s, "\n")
+	if len(line) > n {
+		for n > 0 && !utf8.RuneStart(line[n]) {
+			n--
+		}
+		line, more = line[:n], true
+	}
+	if more {
+		line += "..."
+	}
+	return line
+}
+
+// confirm asks the question on standard error and reports
+// whether the answer read from standard input is yes.
+func confirm(question string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	var answer string
+	fmt.Fscanln(os.Stdin, &answer)
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes"
+}
+
+func bulkEditStart(issues []*schema.Issue) (*schema.Issue, []byte) {
+	common := new(schema.Issue)
 	for i, issue := range issues {
 		if i == 0 {
 			common.State = issue.State
-			common.Assignee = issue.Assignee
+			common.Assignees = issue.Assignees
 			common.Labels = issue.Labels
 			common.Milestone = issue.Milestone
 			continue
 		}
-		if common.State != nil && getString(common.State) != getString(issue.State) {
-			common.State = nil
+		if common.State != "" && common.State != issue.State {
+			common.State = ""
 		}
-		if common.Assignee != nil && getUserLogin(common.Assignee) != getUserLogin(issue.Assignee) {
-			common.Assignee = nil
+		if common.Assignees != nil && getAssignee(common.Assignees) != getAssignee(issue.Assignees) {
+			common.Assignees = nil
 		}
 		if common.Milestone != nil && getMilestoneTitle(common.Milestone) != getMilestoneTitle(issue.Milestone) {
 			common.Milestone = nil
@@ -409,14 +836,14 @@ func bulkEditStart(issues []*github.Issue) (*github.Issue, []byte) {
 	}
 
 	var buf bytes.Buffer
-	fmt.Fprintf(&buf, "State: %s\n", getString(common.State))
-	fmt.Fprintf(&buf, "Assignee: %s\n", getUserLogin(common.Assignee))
+	fmt.Fprintf(&buf, "State: %s\n", getState(common))
+	fmt.Fprintf(&buf, "Assignee: %s\n", getAssignee(common.Assignees))
 	fmt.Fprintf(&buf, "Labels: %s\n", strings.Join(getLabelNames(common.Labels), " "))
 	fmt.Fprintf(&buf, "Milestone: %s\n", getMilestoneTitle(common.Milestone))
 	fmt.Fprintf(&buf, "\n<optional comment here>\n")
 	fmt.Fprintf(&buf, "%s\n", bulkHeader)
 	for _, issue := range issues {
-		fmt.Fprintf(&buf, "%d\t%s\n", getInt(issue.Number), getString(issue.Title))
+		fmt.Fprintf(&buf, "%d\t%s\n", issue.Number, issue.Title)
 	}
 
 	return common, buf.Bytes()
@@ -429,24 +856,24 @@ func commonString(x, y string) string {
 	return x
 }
 
-func commonLabels(x, y []*github.Label) []*github.Label {
-	if len(x) == 0 || len(y) == 0 {
+func commonLabels(x, y *schema.LabelConnection) *schema.LabelConnection {
+	if x == nil || y == nil || len(x.Nodes) == 0 || len(y.Nodes) == 0 {
 		return nil
 	}
 	have := make(map[string]bool)
-	for _, lab := range y {
-		have[getString(lab.Name)] = true
+	for _, lab := range y.Nodes {
+		have[lab.Name] = true
 	}
-	var out []*github.Label
-	for _, lab := range x {
-		if have[getString(lab.Name)] {
-			out = append(out, lab)
+	out := new(schema.LabelConnection)
+	for _, lab := range x.Nodes {
+		if have[lab.Name] {
+			out.Nodes = append(out.Nodes, lab)
 		}
 	}
 	return out
 }
 
-func bulkWriteIssue(project string, old *github.Issue, updated []byte, status func(string)) (ids []int, err error) {
+func bulkWriteIssue(project string, old *schema.Issue, updated []byte, status func(string)) (ids []int, err error) {
 	i := bytes.Index(updated, []byte(bulkHeader))
 	if i < 0 {
 		return nil, fmt.Errorf("cannot find bulk edit issue list")
@@ -461,10 +888,8 @@ func bulkWriteIssue(project string, old *github.Issue, updated []byte, status fu
 	old = &x
 
 	// Try a write to issue -1, checking for formatting only.
-	old.Number = new(int)
-	*old.Number = -1
-	_, rate, err := writeIssue(project, old, updated, true)
-	if err != nil {
+	old.Number = -1
+	if _, err := writeIssue(project, old, updated, true); err != nil {
 		return nil, err
 	}
 
@@ -475,32 +900,28 @@ func bulkWriteIssue(project string, old *github.Issue, updated []byte, status fu
 	}
 	status(fmt.Sprintf("updating %d issue%s", len(ids), suffix))
 
-	failed := false
-	for index, number := range ids {
+	// Mutations need the issue IDs, which are usually cached
+	// from the list that the bulk edit started from.
+	issues, err := bulkReadIssuesCached(project, ids)
+	failed := err != nil
+	if err != nil {
+		status(strings.Replace(err.Error(), "\n", "\n\t", -1))
+	}
+
+	// The client waits out GitHub rate limits by itself,
+	// logging each pause, so the loop need not check them.
+	for index, issue := range issues {
 		if index%10 == 0 && index > 0 {
 			status(fmt.Sprintf("updated %d/%d issues", index, len(ids)))
 		}
-		// Check rate limits here (in contrast to everywhere else in this program)
-		// to avoid needless failure halfway through the loop.
-		for rate != nil && rate.Limit > 0 && rate.Remaining == 0 {
-			delta := (rate.Reset.Sub(time.Now())/time.Minute + 2) * time.Minute
-			if delta < 0 {
-				delta = 2 * time.Minute
-			}
-			status(fmt.Sprintf("updated %d/%d issues; pausing %d minutes to respect GitHub rate limit", index, len(ids), int(delta/time.Minute)))
-			time.Sleep(delta)
-			limits, _, err := client.RateLimits(context.TODO())
-			if err != nil {
-				status(fmt.Sprintf("reading rate limit: %v", err))
-			}
-			rate = nil
-			if limits != nil {
-				rate = limits.Core
-			}
+		if issue == nil {
+			continue
 		}
-		*old.Number = number
-		if _, rate, err = writeIssue(project, old, updated, true); err != nil {
-			status(fmt.Sprintf("writing #%d: %s", number, strings.Replace(err.Error(), "\n", "\n\t", -1)))
+		old.Id = issue.Id
+		old.Number = issue.Number
+		old.Url = issue.Url
+		if _, err := writeIssue(project, old, updated, true); err != nil {
+			status(fmt.Sprintf("writing #%d: %s", issue.Number, strings.Replace(err.Error(), "\n", "\n\t", -1)))
 			failed = true
 		}
 	}
@@ -0,0 +1,86 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"maps"
+	"strings"
+	"testing"
+
+	"rsc.io/github/schema"
+)
+
+func editable(blocks ...string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("Title: an issue\n")
+	for i := 0; i+1 < len(blocks); i += 2 {
+		printEditable(&buf, blocks[i], blocks[i+1])
+	}
+	return buf.Bytes()
+}
+
+var checkEditableTests = []struct {
+	name    string
+	updated string
+	edits   map[string]string
+	err     string
+}{
+	{
+		name:    "unchanged",
+		updated: string(editable("I_1", "body", "IC_2", "comment")),
+		edits:   map[string]string{},
+	},
+	{
+		name:    "edited",
+		updated: string(editable("I_1", "new body", "IC_2", "new comment")),
+		edits:   map[string]string{"I_1": "new body", "IC_2": "new comment"},
+	},
+	{
+		name:    "crlf",
+		updated: strings.ReplaceAll(string(editable("I_1", "body", "IC_2", "line 1\nline 2")), "\n", "\r\n"),
+		edits:   map[string]string{"IC_2": "line 1\nline 2"},
+	},
+	{
+		name:    "empty body",
+		updated: string(editable("I_1", "", "IC_2", "comment")),
+		edits:   map[string]string{"I_1": ""},
+	},
+	{
+		name:    "empty comment",
+		updated: string(editable("I_1", "new body", "IC_2", "")),
+		err:     "cannot save empty comment IC_2",
+	},
+	{
+		name:    "damaged marker",
+		updated: strings.Replace(string(editable("I_1", "new body", "IC_2", "comment")), "<!-- end IC_2 -->", "<!-- end IC_2", 1),
+		err:     "cannot find edited text IC_2",
+	},
+}
+
+func TestCheckEditable(t *testing.T) {
+	issue := &schema.Issue{Id: "I_1"}
+	original := editable("I_1", "body", "IC_2", "comment")
+	for _, tt := range checkEditableTests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits, err := checkEditable(issue, original, []byte(tt.updated))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("checkEditable: err = %v, want %q", err, tt.err)
+				}
+				if edits != nil {
+					t.Errorf("checkEditable returned edits %v with error", edits)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !maps.Equal(edits, tt.edits) {
+				t.Errorf("checkEditable = %v, want %v", edits, tt.edits)
+			}
+		})
+	}
+}
@@ -11,16 +11,82 @@ Issue runs the query against the given project's issue tracker and
 prints a table of matching issues, sorted by issue summary.
 The default owner/repo is golang/go.
 
+To work with a GitHub Enterprise Server instance instead of github.com,
+use the -host flag, as in -host github.example.com, or prefix the
+project with the host, as in -p github.example.com/owner/repo.
+
+The -p flag can be repeated to search several projects at once,
+and it can name just an owner, as in -p golang, to search all of
+that owner's repositories. When searching more than one repository,
+issue identifies each result by owner/repo#number, as in golang/go#1234,
+and groups the results by repository. Only searches can span repositories:
+viewing or editing an issue requires a single owner/repo.
+
 If multiple arguments are given as the query, issue joins them by
 spaces to form a single issue search. These two commands are equivalent:
 
 	issue assignee:rsc author:robpike
 	issue "assignee:rsc author:robpike"
 
-Searches are always limited to open issues.
+Searches are limited to open issues unless the query says otherwise,
+using state:closed or is:closed for closed issues, or state:all for
+both open and closed issues:
+
+	issue state:closed milestone:Go1.21 label:release-blocker
+
+The -since flag limits the results to issues updated at or after
+a given time, specified either as a duration before the current time,
+like 24h, or as a date or time, like 2024-01-02 or 2024-01-02T15:04:05Z.
+It is equivalent to adding updated:>=time to the query, and it can be
+used without a query, as in this command to list the open issues
+changed in the last week:
+
+	issue -since 168h
+
+The -n flag limits the number of results to fetch. When -n is given,
+issue prints the results in the order GitHub returns them rather than
+sorting them, so that for example
+
+	issue -n 20 label:Proposal sort:updated
+
+prints the 20 most recently updated proposals.
+
+GitHub search returns at most 1000 results for a query. When more issues
+than that match, issue splits the search into a sequence of searches,
+each limited to issues created in a particular time window
+(or updated, if the query already uses created:), and combines the results.
+
+The -watch flag causes issue to repeat the query at the given interval,
+such as 1m, printing only the issues that are new or have changed
+since the previous run. The first run prints all matching issues.
+After that, issue limits each query to issues updated since the
+previous run, to keep the cost of each query low.
+
+Frequently used searches can be saved in $HOME/.github-issue-searches,
+one per line, each giving a name and a query:
+
+	# Issues waiting for a first look.
+	triage = label:NeedsTriage -label:WaitingForInfo
+	mine = assignee:@me sort:updated
+
+A word of the form @name in a query stands for the saved search
+with that name, so “issue @triage” lists the issues needing triage,
+and “issue @triage label:compiler/runtime” narrows that list further.
 
 If the query is a single number, issue prints that issue in detail,
 including all comments.
+Issue and comment text is formatted for reading as plain text:
+paragraphs, list items, and block quotes are wrapped to fit the window,
+code blocks are left exactly as written, and links are replaced by
+numbered references listed after the text, as in
+“see the docs[1]” followed by “[1] https://go.dev/doc”.
+The -raw flag prints the text exactly as written instead.
+
+When printing to a terminal, issue colors its output, showing issue states,
+milestones, and labels in color, using each label's color from GitHub.
+In color output, the search results also list each issue's milestone and labels.
+The -color flag controls coloring: auto (the default) colors output
+to a terminal, unless $NO_COLOR is set; always and never force the choice.
 
 # Authentication
 
@@ -32,6 +98,30 @@ The token only needs the 'repo' scope checkbox, and optionally 'private_repo'
 if you want to work with issue trackers for private repositories.
 It does not need any other permissions.
 The -token flag specifies an alternate file from which to read the token.
+When using a GitHub Enterprise Server host, issue reads the token from
+$HOME/.github-issue-token-<host> instead, or else uses the .netrc entry
+or gh token for that host.
+
+The -keychain flag causes issue to read the token from the system
+keychain (the macOS Keychain, the Secret Service on Linux, using
+secret-tool from libsecret, or the Windows Credential Manager)
+instead of from a file. To move a token from its file into the keychain,
+run “issue -keychain import”, which copies the token that issue would
+otherwise read from the file (respecting -token, -profile, -p, and -host)
+into the keychain. After that, the file can be deleted.
+
+To use different tokens for different organizations, such as one
+for work and one for personal projects, save each token in a profile file
+named $HOME/.github-issue-token-<profile>. The -profile flag selects the
+profile to use. Without -profile or -token, if all the -p projects have
+the same owner and a profile named for that owner exists, issue uses it.
+For example, $HOME/.github-issue-token-mycorp is used automatically
+for -p mycorp/server.
+
+If $HOME/.github-issue-token does not exist, issue uses the same
+credentials as other programs built on rsc.io/github:
+the api.github.com entry in $HOME/.netrc, or else the token
+stored by the gh command (see “gh auth login”).
 
 # Acme Editor Integration
 
@@ -54,7 +144,7 @@ and open a window (or navigate to an existing one).
 Executing "New" opens an issue creation window.
 
 Executing "Search <query>" opens a new window showing the
-results of that search.
+results of that search. The query can use saved searches, as in "Search @triage".
 
 # Issue Window
 
@@ -85,7 +175,46 @@ Executing "Get" reloads the issue data.
 Executing "Put" updates an issue. It saves any changes to the issue header
 and, if any text has been entered between the header and the "Reported by" line,
 posts that text as a new comment. If both succeed, Put then reloads the issue data.
-The "Closed" and "URL" headers cannot be changed.
+The "Closed", "URL", and "Project" headers cannot be changed.
+
+After the issue description, the window lists the pull requests linked
+to the issue, meaning those that will close it when merged, and then the
+other issues and pull requests that mention it, each with its state:
+
+	Linked pull requests:
+
+		#8790 [merged] time: implement Formatter for Duration
+
+	Referenced by:
+
+		golang/tools#123 [open] x/tools: format durations
+
+If the issue is on any project boards, the header also shows
+each board's name and the issue's Status column on that board:
+
+	Project: Proposals (Active)
+
+When the issue number refers to a pull request, the header also shows
+the pull request's review decision and latest reviews, the reviewers
+whose review has been requested, the combined status of the checks
+on its head commit, and the number of changed files.
+The changed files are listed after the pull request description,
+and reviews and merges appear in the history along with comments.
+For example:
+
+	Title: net/http: fix race in Transport
+	State: open
+	Assignee:
+	Labels:
+	Milestone:
+	URL: https://github.com/golang/go/pull/12345
+	Reactions:
+	Review: changes requested (bradfitz changes requested)
+	Reviewers: neild
+	Checks: success
+	Files: 2 (+30 -4)
+
+These headers cannot be changed either.
 
 # Issue Creation Window
 
@@ -190,12 +319,128 @@ changes from the file to the actual issues.
 
 When <query> is a single number, issue -e edits a single issue.
 See the “Issue Window” section above.
+In addition, the issue description (if you can edit it) and the comments
+you have written appear as unformatted text between marker lines,
+which can be edited to change the description and comments:
+
+	Comment by you (2015-01-08 05:17:06)
+
+	<!-- begin IC_kwDOAAAAAAAAAAAA: edit text below to change it -->
+	time must not depend on fmt.
+	<!-- end IC_kwDOAAAAAAAAAAAA -->
+
+The marker lines must be left as they are.
+If one is changed or deleted, issue reports an error
+rather than guessing where the text ends.
 
 If the <query> is the text "new", issue -e creates a new issue.
 See the “Issue Creation Window” section above.
 
 Otherwise, for general queries, issue -e edits multiple issues in bulk.
 See the “Bulk Edit Window” section above.
+Before making any changes, issue -e prints the changes it will make
+to each issue, such as labels added and removed or a new milestone,
+and asks for confirmation. The -y flag skips the confirmation.
+
+# Commands
+
+Issue also provides commands for making common changes without
+an editor, as in scripts. A command is recognized only when followed
+by its usual arguments, so that, for example, “issue comment crash”
+still searches for issues matching “comment crash”.
+
+To create an issue, use “issue new” followed by flags giving the
+issue's content:
+
+	issue new -title 'x/tools: crash' -body-file crash.txt -label NeedsInvestigation
+
+The flags are -title (required), -body or -body-file (a file name,
+or - for standard input), -label (a comma-separated list of labels),
+-milestone, and -assignee. Issue prints the new issue's number and URL.
+
+To comment on an issue, use “issue comment N”, which reads the comment
+from standard input, or “issue comment N -m text”.
+
+To close an issue, use “issue close N”. The -reason flag gives
+the reason for closing: completed (the default) or not-planned.
+The GitHub API version used by issue has no duplicate reason,
+so -reason duplicate closes the issue as not planned;
+use “issue dup” instead. The -m flag adds a comment before closing the issue.
+To reopen an issue, use “issue reopen N”, which also accepts -m.
+
+To add or remove labels, use “issue label” followed by one or more
+issue numbers and then the label changes, +name to add a label and
+-name to remove one:
+
+	issue label 1234 1235 +NeedsFix -WaitingForInfo
+
+To move issues to a milestone, use “issue milestone” followed by
+one or more issue numbers and then the milestone name, or “none”
+to remove the issues from their milestones:
+
+	issue milestone 1234 1235 Go1.25
+
+To list the open milestones, along with their due dates and
+the number of open issues in each, use “issue milestone list”.
+
+To assign an issue to one or more users, use “issue assign N user...”.
+To remove assignees, use “issue unassign N user...”.
+In both commands, and in the Assignee line when editing an issue,
+@me refers to the authenticated user.
+
+To react to an issue, use “issue react N emoji”, where emoji is one of
+:+1:, :-1:, :laugh:, :confused:, :heart:, :hooray:, :rocket:, or :eyes:.
+The -comment K flag reacts to the K'th comment on the issue instead,
+counting from 1, and the -remove flag removes the reaction:
+
+	issue react 1234 :+1:
+	issue react 1234 -comment 2 -remove :heart:
+
+To lock an issue's conversation, use “issue lock N”. The -reason flag
+gives the reason for locking: off-topic, too-heated, resolved, or spam.
+To unlock the conversation, use “issue unlock N”.
+
+To move an issue to another repository, use “issue transfer N owner/repo”.
+Issue prints the transferred issue's new number and URL.
+
+To close an issue as a duplicate of another, use “issue dup N M”,
+which comments “Duplicate of #M” on issue N, causing GitHub to mark it
+as a duplicate, and closes it as not planned.
+The -labels flag also adds issue N's labels to issue M.
+
+To download the files and images attached to an issue and its comments,
+such as crash reports and screenshots, use “issue download N”.
+It saves the attachments in the directory issue-N, or the directory
+given by the -d flag, along with the issue text in issue.txt, with each
+reference to an attachment rewritten to the name of the downloaded file.
+Attachments in private repositories may not be downloadable this way,
+since GitHub only serves them to a logged-in browser.
+
+To make different changes to many issues, use “issue apply file.csv”,
+which reads a CSV file (or, for a file named *.tsv, tab-separated values;
+or, for -, standard input) listing one issue per row.
+The first row names the columns: number, which is required,
+and any of labels, milestone, assignee, state, and comment.
+An empty cell leaves that part of the issue unchanged,
+and “none” removes the issue's labels, milestone, or assignees.
+The labels cell can list changes, like “+NeedsFix -WaitingForInfo”,
+or else the complete list of labels for the issue.
+For example:
+
+	number,labels,milestone,comment
+	1234,+NeedsFix,Go1.25,
+	1235,-NeedsFix,none,Not for this release.
+
+The columns written by -csv are also allowed and ignored,
+so the output of “issue -csv all <query>” can be edited and applied.
+Issue apply prints the result for each row and continues past errors.
+
+To check whether the token has run out of GitHub API quota, use
+“issue ratelimit”, which prints the requests remaining for the core (REST)
+and search APIs and the points remaining for the GraphQL API,
+along with the time that each limit resets.
+The -v flag causes any issue command to print the remaining
+GraphQL points to standard error when it finishes.
 
 # JSON Output
 
@@ -217,6 +462,14 @@ using these data structures:
 		Text      string
 		Comments  []*Comment
 		Reactions Reactions
+
+		Projects    []*Project   `json:",omitempty"`
+		PullRequest *PullRequest `json:",omitempty"`
+	}
+
+	type Project struct {
+		Title  string
+		Status string
 	}
 
 	type Comment struct {
@@ -237,15 +490,64 @@ using these data structures:
 		Eyes      int
 	}
 
-If asked for a specific issue, the output is an Issue with Comments.
+	type PullRequest struct {
+		Review    string
+		Reviews   []*Review
+		Reviewers []string
+		Checks    string
+		Files     []*File
+	}
+
+	type Review struct {
+		Author string
+		State  string
+	}
+
+	type File struct {
+		Path      string
+		Additions int
+		Deletions int
+	}
+
+If asked for a specific issue, the output is an Issue with Comments,
+along with the Projects that the issue is on.
+If that issue is a pull request, the Issue also has a PullRequest.
 Otherwise, the result is an array of Issues without Comments.
+
+# Formatted Output
+
+The -format flag specifies an alternate output format, using the
+syntax of package text/template. The template is applied to each
+result, an Issue as described in the previous section, and the
+output of each is followed by a newline. For example, to print
+the number and labels of each matching issue:
+
+	issue -format '{{.Number}} {{join .Labels ","}}' label:NeedsFix
+
+As with -json, if asked for a specific issue, the template is applied
+to an Issue with Comments; otherwise it is applied to each Issue
+without Comments. In addition to the standard template functions,
+the template can call join, which is strings.Join.
+
+# CSV Output
+
+The -csv flag causes issue to print the results as CSV, for use in
+spreadsheets, with one row for each issue after a header row.
+The flag's value is a comma-separated list of columns to print,
+chosen from repo, number, title, labels, milestone, assignee,
+created, and updated, or else "all" for all of them. For example:
+
+	issue -csv number,title,assignee milestone:Go1.23
+
+The -tsv flag is like -csv but separates columns with tabs.
 */
 package main // import "rsc.io/github/issue"
 
 import (
 	"bytes"
-	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -254,26 +556,61 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
-	"github.com/google/go-github/v62/github"
-	"golang.org/x/oauth2"
+	"rsc.io/github"
+	"rsc.io/github/schema"
 )
 
 var (
 	acmeFlag  = flag.Bool("a", false, "open in new acme window")
 	editFlag  = flag.Bool("e", false, "edit in system editor")
+	yesFlag   = flag.Bool("y", false, "apply -e bulk edits without asking for confirmation")
 	jsonFlag  = flag.Bool("json", false, "write JSON output")
-	project   = flag.String("p", "golang/go", "GitHub owner/repo name")
+	format    = flag.String("format", "", "format output using text/template `tmpl`")
+	sinceFlag = flag.String("since", "", "only show issues updated since `time` (a duration like 24h or a time like 2024-01-02)")
+	watchFlag = flag.Duration("watch", 0, "repeat the query every `interval`, printing new and changed issues")
+	colorFlag = flag.String("color", "auto", "color output: `mode` auto, always, or never")
+	maxFlag   = flag.Int("n", 0, "fetch at most `n` results (0 means no limit)")
+	csvFlag   = flag.String("csv", "", "write CSV output with the comma-separated `columns`")
+	tsvFlag   = flag.String("tsv", "", "write tab-separated output with the comma-separated `columns`")
+	projects  projectList
 	rawFlag   = flag.Bool("raw", false, "do no processing of markdown")
 	tokenFile = flag.String("token", "", "read GitHub token personal access token from `file` (default $HOME/.github-issue-token)")
+	hostFlag  = flag.String("host", "github.com", "GitHub or GitHub Enterprise Server `host`")
+	keychain  = flag.Bool("keychain", false, "read GitHub token from the system keychain")
+	profile   = flag.String("profile", "", "read GitHub token from $HOME/.github-issue-token-`name`")
 	logHTTP   = flag.Bool("loghttp", false, "log http requests")
+	verbose   = flag.Bool("v", false, "print the remaining GraphQL rate limit when done")
 )
 
+// project is the single owner/repo from the -p flag.
+// It is empty when -p lists more than one project or names only an owner.
+var project string
+
+func init() {
+	flag.Var(&projects, "p", "GitHub owner/repo name, or owner for all of owner's repos; can be repeated (default golang/go)")
+}
+
+// A projectList is a flag.Value holding the list of -p flags.
+type projectList []string
+
+func (p *projectList) String() string { return strings.Join(*p, ",") }
+
+func (p *projectList) Set(s string) error {
+	if f := strings.Split(s, "/"); len(f) > 3 || slices.Contains(f, "") {
+		return fmt.Errorf("must be owner/repo, like golang/go, owner, like golang, or host/owner/repo")
+	}
+	*p = append(*p, s)
+	return nil
+}
+
 func usage() {
 	fmt.Fprintf(os.Stderr, `usage: issue [-a] [-e] [-p owner/repo] <query>
 
@@ -281,7 +618,30 @@ If query is a single number, prints the full history for the issue.
 Otherwise, prints a table of matching results.
 `)
 	flag.PrintDefaults()
-	os.Exit(2)
+	exit(2)
+}
+
+// exit exits the program with the given status,
+// first printing the rate limit summary if -v is set.
+// The program must call exit (or fatal or fatalf) instead of
+// os.Exit or log.Fatal, which would skip the summary.
+func exit(code int) {
+	if *verbose {
+		printRateSummary()
+	}
+	os.Exit(code)
+}
+
+// fatal is like log.Fatal but calls exit.
+func fatal(v ...any) {
+	log.Print(v...)
+	exit(1)
+}
+
+// fatalf is like log.Fatalf but calls exit.
+func fatalf(format string, v ...any) {
+	log.Printf(format, v...)
+	exit(1)
 }
 
 func main() {
@@ -290,36 +650,126 @@ func main() {
 	log.SetFlags(0)
 	log.SetPrefix("issue: ")
 
-	if flag.NArg() == 0 && !*acmeFlag {
+	if flag.NArg() == 0 && !*acmeFlag && *sinceFlag == "" {
 		usage()
 	}
 
 	if *jsonFlag && *acmeFlag {
-		log.Fatal("cannot use -a with -json")
+		fatal("cannot use -a with -json")
 	}
 	if *jsonFlag && *editFlag {
-		log.Fatal("cannot use -e with -acme")
+		fatal("cannot use -e with -acme")
+	}
+	if *format != "" {
+		if *jsonFlag || *acmeFlag || *editFlag {
+			fatal("cannot use -format with -a, -e, or -json")
+		}
+		t, err := template.New("format").Funcs(template.FuncMap{"join": strings.Join}).Parse(*format)
+		if err != nil {
+			fatal(err)
+		}
+		formatTmpl = t
+	}
+	if *csvFlag != "" || *tsvFlag != "" {
+		if *jsonFlag || *acmeFlag || *editFlag || *format != "" || *csvFlag != "" && *tsvFlag != "" {
+			fatal("cannot use -csv or -tsv with -a, -e, -format, -json, or each other")
+		}
+		cols := *csvFlag
+		if *tsvFlag != "" {
+			cols = *tsvFlag
+			csvComma = '\t'
+		}
+		if err := parseCSVColumns(cols); err != nil {
+			fatal(err)
+		}
+	}
+
+	if err := setColor(*colorFlag); err != nil {
+		fatal(err)
+	}
+	if *acmeFlag || *editFlag {
+		useColor = false
 	}
 
 	if *logHTTP {
 		http.DefaultTransport = newLogger(http.DefaultTransport)
 	}
+	if *verbose {
+		defer printRateSummary()
+	}
 
-	f := strings.Split(*project, "/")
-	if len(f) != 2 {
-		log.Fatal("invalid form for -p argument: must be owner/repo, like golang/go")
+	if len(projects) == 0 {
+		projects = projectList{"golang/go"}
+	}
+	hostSet := false
+	flag.Visit(func(f *flag.Flag) { hostSet = hostSet || f.Name == "host" })
+	for i, p := range projects {
+		if f := strings.Split(p, "/"); len(f) == 3 {
+			if hostSet && f[0] != *hostFlag {
+				fatalf("-p %s does not match -host %s", p, *hostFlag)
+			}
+			*hostFlag, hostSet = f[0], true
+			projects[i] = f[1] + "/" + f[2]
+		}
+	}
+	if len(projects) == 1 && strings.Contains(projects[0], "/") {
+		project = projects[0]
+	}
+
+	q := strings.Join(flag.Args(), " ")
+	if lookupCommand(flag.Args()) == nil {
+		var err error
+		q, err = expandSearches(q)
+		if err != nil {
+			fatal(err)
+		}
+	}
+	if n, _ := strconv.Atoi(q); project == "" && (*acmeFlag || *editFlag || n != 0) {
+		fatal("cannot use -a, -e, or an issue number with multiple projects or an owner-only -p")
+	}
+	if *watchFlag > 0 {
+		if n, _ := strconv.Atoi(q); n != 0 || *acmeFlag || *editFlag {
+			fatal("cannot use -watch with -a, -e, or an issue number")
+		}
+	}
+	if *sinceFlag != "" {
+		if n, _ := strconv.Atoi(q); n != 0 || *acmeFlag {
+			fatal("cannot use -since with -a or an issue number")
+		}
+		t, err := parseSince(*sinceFlag)
+		if err != nil {
+			fatal(err)
+		}
+		q = strings.TrimSpace(q + " updated:>=" + t.UTC().Format(time.RFC3339))
+	}
+
+	if cmd := lookupCommand(flag.Args()); cmd != nil && !*editFlag && !*acmeFlag {
+		if project == "" {
+			fatalf("cannot use %s with multiple projects or an owner-only -p", cmd.name)
+		}
+		loadAuth()
+		cmd.run(project, flag.Args()[1:])
+		return
+	}
+
+	if *keychain && q == "import" {
+		importToken()
+		return
 	}
 
 	loadAuth()
 
+	if q == "ratelimit" && !*acmeFlag && !*editFlag {
+		showRateLimit(os.Stdout)
+		return
+	}
+
 	if *acmeFlag {
 		acmeMode()
 	}
 
-	q := strings.Join(flag.Args(), " ")
-
 	if *editFlag && q == "new" {
-		editIssue(*project, []byte(createTemplate), new(github.Issue))
+		editIssue(project, []byte(createTemplate), new(schema.Issue))
 		return
 	}
 
@@ -327,241 +777,989 @@ func main() {
 	if n != 0 {
 		if *editFlag {
 			var buf bytes.Buffer
-			issue, err := showIssue(&buf, *project, n)
+			editableText = true
+			issue, err := showIssue(&buf, project, n)
 			if err != nil {
-				log.Fatal(err)
+				fatal(err)
 			}
-			editIssue(*project, buf.Bytes(), issue)
+			editIssue(project, buf.Bytes(), issue)
 			return
 		}
-		if _, err := showIssue(os.Stdout, *project, n); err != nil {
-			log.Fatal(err)
+		if _, err := showIssue(os.Stdout, project, n); err != nil {
+			fatal(err)
 		}
 		return
 	}
 
 	if *editFlag {
-		all, err := searchIssues(*project, q)
+		all, err := searchIssues(project, q)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 		if len(all) == 0 {
-			log.Fatal("no issues matched search")
+			fatal("no issues matched search")
 		}
 		sort.Sort(issuesByTitle(all))
-		bulkEditIssues(*project, all)
+		bulkEditIssues(project, all)
 		return
 	}
 
-	if err := showQuery(os.Stdout, *project, q); err != nil {
-		log.Fatal(err)
+	if *watchFlag > 0 {
+		watchQuery(os.Stdout, projects, q, *watchFlag)
+	}
+
+	if err := showQuery(os.Stdout, projects, q); err != nil {
+		fatal(err)
 	}
 }
 
-func showIssue(w io.Writer, project string, n int) (*github.Issue, error) {
-	issue, _, err := client.Issues.Get(context.TODO(), projectOwner(project), projectRepo(project), n)
+// issueFields are the GraphQL fields of an issue that issue displays and edits.
+const issueFields = `
+  id
+  number
+  title
+  state
+  closedAt
+  createdAt
+  updatedAt
+  url
+  body
+  viewerCanUpdate
+  author { __typename login }
+  assignees(first: 1) { nodes { login } }
+  labels(first: 100) { nodes { id name color } }
+  milestone { id number title }
+  ` + reactionFields + `
+`
+
+const reactionFields = `reactionGroups { content reactors { totalCount } }`
+
+const commitFields = `
+  oid
+  message
+  author { name email date }
+  committer { name email date }
+`
+
+// commentTypes and eventTypes are the kinds of timeline items that printIssue shows.
+// GitHub's mentioned, subscribed, and unsubscribed events are omitted.
+// The comments and events are fetched separately, so that they can be fetched in parallel.
+const commentTypes = `
+  ISSUE_COMMENT
+`
+
+const eventTypes = `
+  CLOSED_EVENT
+  REOPENED_EVENT
+  REFERENCED_EVENT
+  ASSIGNED_EVENT
+  UNASSIGNED_EVENT
+  LABELED_EVENT
+  UNLABELED_EVENT
+  MILESTONED_EVENT
+  DEMILESTONED_EVENT
+  RENAMED_TITLE_EVENT
+  LOCKED_EVENT
+  UNLOCKED_EVENT
+  PINNED_EVENT
+  UNPINNED_EVENT
+  TRANSFERRED_EVENT
+  MARKED_AS_DUPLICATE_EVENT
+  UNMARKED_AS_DUPLICATE_EVENT
+  CROSS_REFERENCED_EVENT
+  CONNECTED_EVENT
+  DISCONNECTED_EVENT
+`
+
+const timelineFields = `
+  __typename
+  ... on IssueComment { id author { __typename login } createdAt body viewerDidAuthor ` + reactionFields + ` }
+  ... on ClosedEvent { actor { __typename login } createdAt closer { __typename ... on Commit { ` + commitFields + ` } } }
+  ... on ReopenedEvent { actor { __typename login } createdAt }
+  ... on ReferencedEvent { actor { __typename login } createdAt commit { ` + commitFields + ` } }
+  ... on AssignedEvent { actor { __typename login } createdAt assignee { __typename ... on Actor { login } } }
+  ... on UnassignedEvent { actor { __typename login } createdAt assignee { __typename ... on Actor { login } } }
+  ... on LabeledEvent { actor { __typename login } createdAt label { name } }
+  ... on UnlabeledEvent { actor { __typename login } createdAt label { name } }
+  ... on MilestonedEvent { actor { __typename login } createdAt milestoneTitle }
+  ... on DemilestonedEvent { actor { __typename login } createdAt milestoneTitle }
+  ... on CrossReferencedEvent { createdAt willCloseTarget source { __typename ` + referenceFields + ` } }
+  ... on ConnectedEvent { createdAt source { __typename ` + referenceFields + ` } subject { __typename ` + referenceFields + ` } }
+  ... on DisconnectedEvent { createdAt source { __typename ` + referenceFields + ` } subject { __typename ` + referenceFields + ` } }
+  ... on RenamedTitleEvent { actor { __typename login } createdAt previousTitle currentTitle }
+  ... on LockedEvent { actor { __typename login } createdAt }
+  ... on UnlockedEvent { actor { __typename login } createdAt }
+  ... on PinnedEvent { actor { __typename login } createdAt }
+  ... on UnpinnedEvent { actor { __typename login } createdAt }
+  ... on TransferredEvent { actor { __typename login } createdAt }
+  ... on MarkedAsDuplicateEvent { actor { __typename login } createdAt }
+  ... on UnmarkedAsDuplicateEvent { actor { __typename login } createdAt }
+`
+
+// pullRequestFields are the GraphQL fields of a pull request that issue displays.
+// They include the issueFields, which pull requests share with issues.
+const pullRequestFields = issueFields + `
+  reviewDecision
+  latestReviews(first: 100) { nodes { author { __typename login } state } }
+  reviewRequests(first: 100) {
+    nodes {
+      requestedReviewer {
+        __typename
+        ... on User { login }
+        ... on Mannequin { login }
+        ... on Team { combinedSlug }
+      }
+    }
+  }
+  commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+  changedFiles
+  additions
+  deletions
+  files(first: 100) { nodes { path additions deletions } }
+`
+
+// referenceFields are the GraphQL fields of an issue or pull request
+// that refers to another, as listed by printReferences.
+const referenceFields = `
+  ... on Issue { number title state url }
+  ... on PullRequest { number title state url }
+`
+
+// pullRequestCommentTypes, pullRequestEventTypes, and pullRequestTimelineFields
+// add reviews and merges to the timeline of a pull request.
+const pullRequestCommentTypes = commentTypes + `
+  PULL_REQUEST_REVIEW
+`
+
+const pullRequestEventTypes = eventTypes + `
+  MERGED_EVENT
+`
+
+const pullRequestTimelineFields = timelineFields + `
+  ... on MergedEvent { actor { __typename login } createdAt commit { ` + commitFields + ` } }
+  ... on PullRequestReview { author { __typename login } createdAt state body }
+`
+
+// queryPages runs the paginated GraphQL query graphql,
+// which must declare a $Cursor variable, calling page with each result.
+// Page returns the page info of the connection being paginated,
+// or nil if there are no results.
+func queryPages(graphql string, vars github.Vars, page func(*schema.Query) *schema.PageInfo) error {
+	for {
+		q, err := client.GraphQLQuery(graphql, vars)
+		if err != nil {
+			return err
+		}
+		info := page(q)
+		if info == nil || !info.HasNextPage {
+			return nil
+		}
+		vars["Cursor"] = info.EndCursor
+	}
+}
+
+// readIssue returns issue n in project along with its timeline,
+// the comments and events in the issue's history, oldest first.
+// It fetches the comments and the events in parallel,
+// fetching the issue itself along with the first page of comments.
+// If n is a pull request, readIssue also returns the pull request,
+// and the returned issue holds the fields that pull requests share with issues.
+func readIssue(project string, n int) (*schema.Issue, *schema.PullRequest, []any, error) {
+	var (
+		wg               sync.WaitGroup
+		x                any
+		comments, events []any
+		cerr, eerr       error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		x, comments, cerr = readTimeline(project, n, issueFields, pullRequestFields, commentTypes, pullRequestCommentTypes)
+	}()
+	go func() {
+		defer wg.Done()
+		_, events, eerr = readTimeline(project, n, "", "", eventTypes, pullRequestEventTypes)
+	}()
+	wg.Wait()
+	if cerr != nil {
+		return nil, nil, nil, cerr
+	}
+	if eerr != nil {
+		return nil, nil, nil, eerr
+	}
+
+	var issue *schema.Issue
+	var pr *schema.PullRequest
+	switch x := x.(type) {
+	case *schema.Issue:
+		issue = x
+	case *schema.PullRequest:
+		pr = x
+		issue = pullRequestIssue(x)
+	}
+	if issue == nil {
+		return nil, nil, nil, fmt.Errorf("%s#%d: no such issue", project, n)
+	}
+
+	timeline := append(comments, events...)
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return getCreatedAt(timeline[i]) < getCreatedAt(timeline[j])
+	})
+	return issue, pr, timeline, nil
+}
+
+// readTimeline reads the timeline items of issue or pull request n in project,
+// returning the *schema.Issue or *schema.PullRequest from the first page of results
+// along with the timeline items. The issue or pull request has the given
+// issue or pull request fields, and the timeline has items of the given types.
+func readTimeline(project string, n int, issueFields, prFields, issueTypes, prTypes string) (any, []any, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Number: Int!, $Cursor: String) {
+	    repository(owner: $Org, name: $Repo) {
+	      issueOrPullRequest(number: $Number) {
+	        __typename
+	        ... on Issue {
+	          ` + issueFields + `
+	          timelineItems(first: 100, after: $Cursor, itemTypes: [` + issueTypes + `]) {
+	            pageInfo { hasNextPage endCursor }
+	            nodes {
+	              ` + timelineFields + `
+	            }
+	          }
+	        }
+	        ... on PullRequest {
+	          ` + prFields + `
+	          timelineItems(first: 100, after: $Cursor, itemTypes: [` + prTypes + `]) {
+	            pageInfo { hasNextPage endCursor }
+	            nodes {
+	              ` + pullRequestTimelineFields + `
+	            }
+	          }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	var first any
+	var timeline []any
+	vars := github.Vars{"Org": projectOwner(project), "Repo": projectRepo(project), "Number": n}
+	err := queryPages(graphql, vars, func(q *schema.Query) *schema.PageInfo {
+		if q.Repository == nil {
+			return nil
+		}
+		switch x := q.Repository.IssueOrPullRequest.Interface.(type) {
+		case *schema.Issue:
+			if first == nil {
+				first = x
+			}
+			if x.TimelineItems == nil {
+				return nil
+			}
+			for _, item := range x.TimelineItems.Nodes {
+				timeline = append(timeline, item.Interface)
+			}
+			return x.TimelineItems.PageInfo
+		case *schema.PullRequest:
+			if first == nil {
+				first = x
+			}
+			if x.TimelineItems == nil {
+				return nil
+			}
+			for _, item := range x.TimelineItems.Nodes {
+				timeline = append(timeline, item.Interface)
+			}
+			return x.TimelineItems.PageInfo
+		}
+		return nil
+	})
+	return first, timeline, err
+}
+
+// pullRequestIssue returns an issue holding the fields of pr
+// that pull requests share with issues.
+func pullRequestIssue(pr *schema.PullRequest) *schema.Issue {
+	return &schema.Issue{
+		Id:              pr.Id,
+		Number:          pr.Number,
+		Title:           pr.Title,
+		State:           schema.IssueState(pr.State),
+		ClosedAt:        pr.ClosedAt,
+		CreatedAt:       pr.CreatedAt,
+		UpdatedAt:       pr.UpdatedAt,
+		Url:             pr.Url,
+		Body:            pr.Body,
+		Author:          pr.Author,
+		Assignees:       pr.Assignees,
+		Labels:          pr.Labels,
+		Milestone:       pr.Milestone,
+		ReactionGroups:  pr.ReactionGroups,
+		ViewerCanUpdate: pr.ViewerCanUpdate,
+	}
+}
+
+// issueProject returns the owner/repo of the project containing issue,
+// taken from the issue's URL.
+func issueProject(issue *schema.Issue) string {
+	path := strings.TrimPrefix(string(issue.Url), "https://")
+	f := strings.SplitN(path, "/", 4)
+	if len(f) < 3 {
+		return ""
+	}
+	return f[1] + "/" + f[2]
+}
+
+// isPullRequest reports whether issue, as returned by readIssue, is a pull request.
+func isPullRequest(issue *schema.Issue) bool {
+	return strings.Contains(string(issue.Url), "/pull/")
+}
+
+// getIssue returns issue n in project, without its timeline.
+func getIssue(project string, n int) (*schema.Issue, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Number: Int!) {
+	    repository(owner: $Org, name: $Repo) {
+	      issue(number: $Number) {
+	        ` + issueFields + `
+	      }
+	    }
+	  }
+	`
+
+	q, err := client.GraphQLQuery(graphql, github.Vars{"Org": projectOwner(project), "Repo": projectRepo(project), "Number": n})
 	if err != nil {
 		return nil, err
 	}
-	updateIssueCache(project, issue)
-	return issue, printIssue(w, project, issue)
+	if q.Repository == nil || q.Repository.Issue == nil {
+		return nil, fmt.Errorf("%s#%d: no such issue", project, n)
+	}
+	return q.Repository.Issue, nil
+}
+
+// getIssues returns the issues with the given numbers in project,
+// without their timelines, in the same order as numbers.
+// It fetches many issues in each request.
+// If some issues cannot be fetched, the list has nil entries
+// for those issues, and the error lists the numbers that failed.
+func getIssues(project string, numbers []int) ([]*schema.Issue, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Number: Int!) {
+	    repository(owner: $Org, name: $Repo) {
+	      issue(number: $Number) {
+	        ` + issueFields + `
+	      }
+	    }
+	  }
+	`
+
+	const batch = 50
+	list := make([]*schema.Issue, len(numbers))
+	var errbuf bytes.Buffer
+	for start := 0; start < len(numbers); start += batch {
+		b := client.NewBatch()
+		replies := make([]schema.Query, min(batch, len(numbers)-start))
+		var queries []*github.BatchQuery
+		for i := range replies {
+			vars := github.Vars{"Org": projectOwner(project), "Repo": projectRepo(project), "Number": numbers[start+i]}
+			queries = append(queries, b.Query(graphql, vars, &replies[i]))
+		}
+		if err := b.Do(); err != nil {
+			return list, err
+		}
+		for i, q := range queries {
+			n := numbers[start+i]
+			switch r := replies[i].Repository; {
+			case q.Err != nil:
+				fmt.Fprintf(&errbuf, "%s#%d: %v\n", project, n, q.Err)
+			case r == nil || r.Issue == nil:
+				fmt.Fprintf(&errbuf, "%s#%d: no such issue\n", project, n)
+			default:
+				list[start+i] = r.Issue
+			}
+		}
+	}
+	if errbuf.Len() > 0 {
+		return list, errors.New(strings.TrimSpace(errbuf.String()))
+	}
+	return list, nil
+}
+
+func showIssue(w io.Writer, project string, n int) (*schema.Issue, error) {
+	// Read the project items in parallel with the rest of the issue.
+	items := make(chan *schema.ProjectV2ItemConnection, 1)
+	go func() { items <- readProjectItems(project, n) }()
+
+	issue, pr, timeline, err := readIssue(project, n)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		updateIssueCache(project, issue)
+	}
+	issue.ProjectItems = <-items
+	return issue, printIssue(w, project, issue, pr, timeline)
+}
+
+// readProjectItems returns the project board items for issue n in project,
+// or nil if they cannot be read. Reading project boards requires
+// the read:project token scope, which many tokens lack,
+// so readProjectItems reads them separately from the rest of the issue
+// and ignores any errors.
+func readProjectItems(project string, n int) *schema.ProjectV2ItemConnection {
+	const fields = `
+	  projectItems(first: 20) {
+	    nodes {
+	      project { title }
+	      fieldValueByName(name: "Status") {
+	        __typename
+	        ... on ProjectV2ItemFieldSingleSelectValue { name }
+	      }
+	    }
+	  }
+	`
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Number: Int!) {
+	    repository(owner: $Org, name: $Repo) {
+	      issueOrPullRequest(number: $Number) {
+	        __typename
+	        ... on Issue { ` + fields + ` }
+	        ... on PullRequest { ` + fields + ` }
+	      }
+	    }
+	  }
+	`
+	q, err := client.GraphQLQuery(graphql, github.Vars{"Org": projectOwner(project), "Repo": projectRepo(project), "Number": n})
+	if err != nil || q.Repository == nil {
+		return nil
+	}
+	switch x := q.Repository.IssueOrPullRequest.Interface.(type) {
+	case *schema.Issue:
+		return x.ProjectItems
+	case *schema.PullRequest:
+		return x.ProjectItems
+	}
+	return nil
 }
 
 const timeFormat = "2006-01-02 15:04:05"
 
-func printIssue(w io.Writer, project string, issue *github.Issue) error {
+func printIssue(w io.Writer, project string, issue *schema.Issue, pr *schema.PullRequest, timeline []any) error {
 	if *jsonFlag {
-		showJSONIssue(w, project, issue)
+		showJSONIssue(w, project, issue, pr, timeline)
 		return nil
 	}
+	if formatTmpl != nil {
+		return showFormat(w, toJSONDetail(project, issue, pr, timeline))
+	}
+	if csvColumns != nil {
+		return showCSV(w, []*schema.Issue{issue})
+	}
 
-	fmt.Fprintf(w, "Title: %s\n", getString(issue.Title))
-	fmt.Fprintf(w, "State: %s\n", getString(issue.State))
-	fmt.Fprintf(w, "Assignee: %s\n", getUserLogin(issue.Assignee))
-	if issue.ClosedAt != nil {
+	fmt.Fprintf(w, "Title: %s\n", issue.Title)
+	fmt.Fprintf(w, "State: %s\n", colorState(getState(issue)))
+	fmt.Fprintf(w, "Assignee: %s\n", getAssignee(issue.Assignees))
+	if issue.ClosedAt != "" {
 		fmt.Fprintf(w, "Closed: %s\n", getTime(issue.ClosedAt).Format(timeFormat))
 	}
-	fmt.Fprintf(w, "Labels: %s\n", strings.Join(getLabelNames(issue.Labels), " "))
-	fmt.Fprintf(w, "Milestone: %s\n", getMilestoneTitle(issue.Milestone))
-	fmt.Fprintf(w, "URL: %s\n", getString(issue.HTMLURL))
-	fmt.Fprintf(w, "Reactions: %v\n", getReactions(issue.Reactions))
-	fmt.Fprintf(w, "\nReported by %s (%s)\n", getUserLogin(issue.User), getTime(issue.CreatedAt).Format(timeFormat))
-	if issue.Body != nil {
-		if *rawFlag {
-			fmt.Fprintf(w, "\n%s\n\n", *issue.Body)
+	fmt.Fprintf(w, "Labels: %s\n", colorLabels(issue.Labels))
+	fmt.Fprintf(w, "Milestone: %s\n", colorMilestone(getMilestoneTitle(issue.Milestone)))
+	fmt.Fprintf(w, "URL: %s\n", issue.Url)
+	fmt.Fprintf(w, "Reactions: %v\n", getReactions(issue.ReactionGroups))
+	for _, item := range z(issue.ProjectItems).Nodes {
+		if status := getProjectStatus(item); status != "" {
+			fmt.Fprintf(w, "Project: %s (%s)\n", z(item.Project).Title, status)
 		} else {
-			text := strings.TrimSpace(*issue.Body)
-			if text != "" {
-				fmt.Fprintf(w, "\n\t%s\n", wrap(text, "\t"))
-			}
+			fmt.Fprintf(w, "Project: %s\n", z(item.Project).Title)
 		}
 	}
+	if pr != nil {
+		j := toJSONPullRequest(pr)
+		review := strings.ToLower(strings.ReplaceAll(j.Review, "_", " "))
+		var reviews []string
+		for _, r := range j.Reviews {
+			reviews = append(reviews, r.Author+" "+strings.ToLower(strings.ReplaceAll(r.State, "_", " ")))
+		}
+		if len(reviews) > 0 {
+			review = strings.TrimSpace(review + " (" + strings.Join(reviews, ", ") + ")")
+		}
+		fmt.Fprintf(w, "Review: %s\n", review)
+		fmt.Fprintf(w, "Reviewers: %s\n", strings.Join(j.Reviewers, " "))
+		fmt.Fprintf(w, "Checks: %s\n", strings.ToLower(j.Checks))
+		fmt.Fprintf(w, "Files: %d (+%d -%d)\n", pr.ChangedFiles, pr.Additions, pr.Deletions)
+	}
+	fmt.Fprintf(w, "\nReported by %s (%s)\n", getUserLogin(issue.Author), getTime(issue.CreatedAt).Format(timeFormat))
+	if editableText && issue.ViewerCanUpdate {
+		printEditable(w, string(issue.Id), issue.Body)
+	} else {
+		printBody(w, issue.Body)
+	}
 
-	var output []string
+	if pr != nil && pr.Files != nil && len(pr.Files.Nodes) > 0 {
+		fmt.Fprintf(w, "\nChanged files:\n\n")
+		for _, f := range pr.Files.Nodes {
+			fmt.Fprintf(w, "\t%s (+%d -%d)\n", f.Path, f.Additions, f.Deletions)
+		}
+		if more := pr.ChangedFiles - len(pr.Files.Nodes); more > 0 {
+			fmt.Fprintf(w, "\t... and %d more\n", more)
+		}
+	}
 
-	for page := 1; ; {
-		list, resp, err := client.Issues.ListComments(context.TODO(), projectOwner(project), projectRepo(project), getInt(issue.Number), &github.IssueListCommentsOptions{
-			ListOptions: github.ListOptions{
-				Page:    page,
-				PerPage: 100,
-			},
-		})
-		for _, com := range list {
-			var buf bytes.Buffer
-			w := &buf
-			fmt.Fprintf(w, "%s\n", getTime(com.CreatedAt).Format(time.RFC3339))
-			fmt.Fprintf(w, "\nComment by %s (%s)\n", getUserLogin(com.User), getTime(com.CreatedAt).Format(timeFormat))
-			if com.Body != nil {
-				if *rawFlag {
-					fmt.Fprintf(w, "\n%s\n\n", *com.Body)
-				} else {
-					text := strings.TrimSpace(*com.Body)
-					if text != "" {
-						fmt.Fprintf(w, "\n\t%s\n", wrap(text, "\t"))
-					}
-				}
+	printReferences(w, project, issue, timeline)
+
+	for _, item := range timeline {
+		switch ev := item.(type) {
+		case *schema.CrossReferencedEvent, *schema.ConnectedEvent, *schema.DisconnectedEvent:
+			// Listed by printReferences.
+		case *schema.IssueComment:
+			fmt.Fprintf(w, "\nComment by %s (%s)\n", getUserLogin(ev.Author), getTime(ev.CreatedAt).Format(timeFormat))
+			if editableText && ev.ViewerDidAuthor {
+				printEditable(w, string(ev.Id), ev.Body)
+			} else {
+				printBody(w, ev.Body)
 			}
-			if r := getReactions(com.Reactions); r != (Reactions{}) {
+			if r := getReactions(ev.ReactionGroups); r != (Reactions{}) {
 				fmt.Fprintf(w, "\n\t%v\n", r)
 			}
+		case *schema.ClosedEvent:
+			commit, _ := ev.Closer.Interface.(*schema.Commit)
+			printCommitEvent(w, ev.Actor, "closed", ev.CreatedAt, commit)
+		case *schema.ReferencedEvent:
+			printCommitEvent(w, ev.Actor, "referenced", ev.CreatedAt, ev.Commit)
+		case *schema.MergedEvent:
+			printCommitEvent(w, ev.Actor, "merged", ev.CreatedAt, ev.Commit)
+		case *schema.PullRequestReview:
+			state := strings.ToLower(strings.ReplaceAll(string(ev.State), "_", " "))
+			fmt.Fprintf(w, "\nReview by %s (%s): %s\n", getUserLogin(ev.Author), getTime(ev.CreatedAt).Format(timeFormat), state)
+			printBody(w, ev.Body)
+		case *schema.AssignedEvent:
+			fmt.Fprintf(w, "\n* %s assigned %s (%s)\n", getUserLogin(ev.Actor), getAssigneeLogin(ev.Assignee), getTime(ev.CreatedAt).Format(timeFormat))
+		case *schema.UnassignedEvent:
+			fmt.Fprintf(w, "\n* %s unassigned %s (%s)\n", getUserLogin(ev.Actor), getAssigneeLogin(ev.Assignee), getTime(ev.CreatedAt).Format(timeFormat))
+		case *schema.LabeledEvent:
+			fmt.Fprintf(w, "\n* %s labeled %s (%s)\n", getUserLogin(ev.Actor), getLabelName(ev.Label), getTime(ev.CreatedAt).Format(timeFormat))
+		case *schema.UnlabeledEvent:
+			fmt.Fprintf(w, "\n* %s unlabeled %s (%s)\n", getUserLogin(ev.Actor), getLabelName(ev.Label), getTime(ev.CreatedAt).Format(timeFormat))
+		case *schema.MilestonedEvent:
+			fmt.Fprintf(w, "\n* %s added to milestone %s (%s)\n", getUserLogin(ev.Actor), ev.MilestoneTitle, getTime(ev.CreatedAt).Format(timeFormat))
+		case *schema.DemilestonedEvent:
+			fmt.Fprintf(w, "\n* %s removed from milestone %s (%s)\n", getUserLogin(ev.Actor), ev.MilestoneTitle, getTime(ev.CreatedAt).Format(timeFormat))
+		case *schema.RenamedTitleEvent:
+			fmt.Fprintf(w, "\n* %s changed title (%s)\n  - %s\n  + %s\n", getUserLogin(ev.Actor), getTime(ev.CreatedAt).Format(timeFormat), ev.PreviousTitle, ev.CurrentTitle)
+		case interface {
+			GetActor() schema.Actor
+			GetCreatedAt() schema.DateTime
+		}:
+			fmt.Fprintf(w, "\n* %s %s (%s)\n", getUserLogin(ev.GetActor()), eventName(ev), getTime(ev.GetCreatedAt()).Format(timeFormat))
+		}
+	}
 
-			output = append(output, buf.String())
+	return nil
+}
+
+// printReferences prints the pull requests linked to issue,
+// meaning those that will close it when merged,
+// followed by the other issues and pull requests that mention it.
+func printReferences(w io.Writer, project string, issue *schema.Issue, timeline []any) {
+	type ref struct {
+		url, text string
+		linked    bool
+	}
+	var refs []*ref
+	byURL := make(map[string]*ref)
+	add := func(subject schema.ReferencedSubject, linked bool) *ref {
+		var number int
+		var title, state string
+		var url schema.URI
+		switch x := subject.Interface.(type) {
+		default:
+			return nil
+		case *schema.Issue:
+			number, title, state, url = x.Number, x.Title, string(x.State), x.Url
+		case *schema.PullRequest:
+			number, title, state, url = x.Number, x.Title, string(x.State), x.Url
 		}
-		if err != nil {
-			return err
+		if url == issue.Url {
+			return nil
 		}
-		if resp.NextPage < page {
-			break
+		r := byURL[string(url)]
+		if r == nil {
+			name := fmt.Sprintf("#%d", number)
+			if p := issueProject(&schema.Issue{Url: url}); p != project {
+				name = p + name
+			}
+			r = &ref{url: string(url), text: fmt.Sprintf("%s [%s] %s", name, strings.ToLower(state), title)}
+			byURL[r.url] = r
+			refs = append(refs, r)
 		}
-		page = resp.NextPage
+		r.linked = r.linked || linked
+		return r
 	}
-
-	for page := 1; ; {
-		list, resp, err := client.Issues.ListIssueEvents(context.TODO(), projectOwner(project), projectRepo(project), getInt(issue.Number), &github.ListOptions{
-			Page:    page,
-			PerPage: 100,
-		})
-		for _, ev := range list {
-			var buf bytes.Buffer
-			w := &buf
-			fmt.Fprintf(w, "%s\n", getTime(ev.CreatedAt).Format(time.RFC3339))
-			switch event := getString(ev.Event); event {
-			case "mentioned", "subscribed", "unsubscribed":
-				// ignore
-			default:
-				fmt.Fprintf(w, "\n* %s %s (%s)\n", getUserLogin(ev.Actor), event, getTime(ev.CreatedAt).Format(timeFormat))
-			case "closed", "referenced", "merged":
-				id := getString(ev.CommitID)
-				if id != "" {
-					if len(id) > 7 {
-						id = id[:7]
-					}
-					id = " in commit " + id
-				}
-				fmt.Fprintf(w, "\n* %s %s%s (%s)\n", getUserLogin(ev.Actor), event, id, getTime(ev.CreatedAt).Format(timeFormat))
-				if id != "" {
-					commit, _, err := client.Git.GetCommit(context.TODO(), projectOwner(project), projectRepo(project), *ev.CommitID)
-					if err == nil {
-						fmt.Fprintf(w, "\n\tAuthor: %s <%s> %s\n\tCommitter: %s <%s> %s\n\n\t%s\n",
-							getString(commit.Author.Name), getString(commit.Author.Email), getTime(commit.Author.Date).Format(timeFormat),
-							getString(commit.Committer.Name), getString(commit.Committer.Email), getTime(commit.Committer.Date).Format(timeFormat),
-							wrap(getString(commit.Message), "\t"))
-					}
-				}
-			case "assigned", "unassigned":
-				fmt.Fprintf(w, "\n* %s %s %s (%s)\n", getUserLogin(ev.Actor), event, getUserLogin(ev.Assignee), getTime(ev.CreatedAt).Format(timeFormat))
-			case "labeled", "unlabeled":
-				fmt.Fprintf(w, "\n* %s %s %s (%s)\n", getUserLogin(ev.Actor), event, getString(ev.Label.Name), getTime(ev.CreatedAt).Format(timeFormat))
-			case "milestoned", "demilestoned":
-				if event == "milestoned" {
-					event = "added to milestone"
-				} else {
-					event = "removed from milestone"
+	for _, item := range timeline {
+		switch ev := item.(type) {
+		case *schema.CrossReferencedEvent:
+			_, isPR := ev.Source.Interface.(*schema.PullRequest)
+			add(ev.Source, isPR && ev.WillCloseTarget)
+		case *schema.ConnectedEvent:
+			if add(ev.Source, true) == nil {
+				add(ev.Subject, true)
+			}
+		case *schema.DisconnectedEvent:
+			for _, s := range []schema.ReferencedSubject{ev.Source, ev.Subject} {
+				if r := add(s, false); r != nil {
+					r.linked = false
 				}
-				fmt.Fprintf(w, "\n* %s %s %s (%s)\n", getUserLogin(ev.Actor), event, getString(ev.Milestone.Title), getTime(ev.CreatedAt).Format(timeFormat))
-			case "renamed":
-				fmt.Fprintf(w, "\n* %s changed title (%s)\n  - %s\n  + %s\n", getUserLogin(ev.Actor), getTime(ev.CreatedAt).Format(timeFormat), getString(ev.Rename.From), getString(ev.Rename.To))
 			}
-			output = append(output, buf.String())
 		}
-		if err != nil {
-			return err
+	}
+
+	for _, linked := range []bool{true, false} {
+		header := "Linked pull requests"
+		if !linked {
+			header = "Referenced by"
 		}
-		if resp.NextPage < page {
-			break
+		printed := false
+		for _, r := range refs {
+			if r.linked != linked {
+				continue
+			}
+			if !printed {
+				fmt.Fprintf(w, "\n%s:\n\n", header)
+				printed = true
+			}
+			fmt.Fprintf(w, "\t%s\n", r.text)
 		}
-		page = resp.NextPage
 	}
+}
 
-	sort.Strings(output)
-	for _, s := range output {
-		i := strings.Index(s, "\n")
-		fmt.Fprintf(w, "%s", s[i+1:])
+// printBody prints the body of an issue or comment.
+func printBody(w io.Writer, body string) {
+	if *rawFlag {
+		fmt.Fprintf(w, "\n%s\n\n", body)
+		return
+	}
+	text := strings.TrimSpace(body)
+	if text != "" {
+		fmt.Fprintf(w, "\n\t%s\n", render(text, "\t"))
 	}
+}
 
-	return nil
+// printCommitEvent prints a closed or referenced event,
+// along with the commit that closed or referenced the issue, if any.
+func printCommitEvent(w io.Writer, actor schema.Actor, event string, created schema.DateTime, commit *schema.Commit) {
+	if commit == nil {
+		fmt.Fprintf(w, "\n* %s %s (%s)\n", getUserLogin(actor), event, getTime(created).Format(timeFormat))
+		return
+	}
+	id := string(commit.Oid)
+	if len(id) > 7 {
+		id = id[:7]
+	}
+	fmt.Fprintf(w, "\n* %s %s in commit %s (%s)\n", getUserLogin(actor), event, id, getTime(created).Format(timeFormat))
+	author, committer := z(commit.Author), z(commit.Committer)
+	fmt.Fprintf(w, "\n\tAuthor: %s <%s> %s\n\tCommitter: %s <%s> %s\n\n\t%s\n",
+		author.Name, author.Email, getTime(schema.DateTime(author.Date)).Format(timeFormat),
+		committer.Name, committer.Email, getTime(schema.DateTime(committer.Date)).Format(timeFormat),
+		wrap(commit.Message, "\t"))
 }
 
-func showQuery(w io.Writer, project, q string) error {
-	all, err := searchIssues(project, q)
+// eventName returns the name GitHub's REST API uses for the timeline event ev,
+// such as "marked_as_duplicate" for a *schema.MarkedAsDuplicateEvent.
+func eventName(ev any) string {
+	name := fmt.Sprintf("%T", ev)
+	name = strings.TrimSuffix(name[strings.LastIndex(name, ".")+1:], "Event")
+	var buf strings.Builder
+	for i, r := range name {
+		if 'A' <= r && r <= 'Z' {
+			if i > 0 {
+				buf.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
+// showQuery prints the results of searching for q in list,
+// a list of projects as in the -p flag.
+func showQuery(w io.Writer, list []string, q string) error {
+	all, err := searchProjects(list, q)
 	if err != nil {
 		return err
 	}
-	sort.Sort(issuesByTitle(all))
+	return printIssues(w, list, all)
+}
+
+// watchQuery runs the search for q in list every interval, forever,
+// printing the issues that are new or changed since the previous search.
+func watchQuery(w io.Writer, list []string, q string, interval time.Duration) {
+	updated := make(map[string]schema.DateTime) // last update time for each issue URL
+	var last time.Time
+	for {
+		start := time.Now()
+		pq := q
+		if !last.IsZero() && !strings.Contains(q, "updated:") {
+			// Overlap with the previous search a little, in case of clock skew.
+			pq = strings.TrimSpace(q + " updated:>=" + last.Add(-1*time.Minute).UTC().Format(time.RFC3339))
+		}
+		all, err := searchProjects(list, pq)
+		if err != nil {
+			log.Print(err)
+		} else {
+			var changed []*schema.Issue
+			for _, issue := range all {
+				if updated[string(issue.Url)] != issue.UpdatedAt {
+					updated[string(issue.Url)] = issue.UpdatedAt
+					changed = append(changed, issue)
+				}
+			}
+			if len(changed) > 0 {
+				if err := printIssues(w, list, changed); err != nil {
+					log.Print(err)
+				}
+			}
+			last = start
+		}
+		time.Sleep(time.Until(start.Add(interval)))
+	}
+}
+
+// printIssues prints the issues in all, the results of
+// searching list, a list of projects as in the -p flag.
+func printIssues(w io.Writer, list []string, all []*schema.Issue) error {
+	if *maxFlag <= 0 {
+		sort.Sort(issuesByTitle(all))
+	}
+	multi := len(list) > 1 || !strings.Contains(list[0], "/")
+	if multi {
+		sort.SliceStable(all, func(i, j int) bool { return issueProject(all[i]) < issueProject(all[j]) })
+	}
 	if *jsonFlag {
-		showJSONList(project, all)
+		showJSONList(all)
+		return nil
+	}
+	if formatTmpl != nil {
+		for _, issue := range all {
+			if err := showFormat(w, toJSON(issueProject(issue), issue)); err != nil {
+				return err
+			}
+		}
 		return nil
 	}
+	if csvColumns != nil {
+		return showCSV(w, all)
+	}
 	for _, issue := range all {
-		fmt.Fprintf(w, "%v\t%v\n", getInt(issue.Number), getString(issue.Title))
+		num := fmt.Sprint(issue.Number)
+		if multi {
+			num = issueProject(issue) + "#" + num
+		}
+		text := issue.Title
+		if useColor {
+			// Colors make room for more information in the same space.
+			num = colorize(num, stateSGR(getState(issue)))
+			if m := getMilestoneTitle(issue.Milestone); m != "" {
+				text += " " + colorMilestone(m)
+			}
+			if labels := colorLabels(issue.Labels); labels != "" {
+				text += " " + labels
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\n", num, text)
 	}
 	return nil
 }
 
-type issuesByTitle []*github.Issue
+type issuesByTitle []*schema.Issue
 
 func (x issuesByTitle) Len() int      { return len(x) }
 func (x issuesByTitle) Swap(i, j int) { x[i], x[j] = x[j], x[i] }
 func (x issuesByTitle) Less(i, j int) bool {
-	if getString(x[i].Title) != getString(x[j].Title) {
-		return getString(x[i].Title) < getString(x[j].Title)
+	if x[i].Title != x[j].Title {
+		return x[i].Title < x[j].Title
 	}
-	return getInt(x[i].Number) < getInt(x[j].Number)
+	return x[i].Number < x[j].Number
 }
 
-func searchIssues(project, q string) ([]*github.Issue, error) {
-	if opt, ok := queryToListOptions(project, q); ok {
-		return listRepoIssues(project, opt)
+// searchProjects returns the issues matching q in list,
+// a list of projects as in the -p flag, each either owner/repo
+// or an owner alone, meaning all of that owner's repositories.
+func searchProjects(list []string, q string) ([]*schema.Issue, error) {
+	var all []*schema.Issue
+	for _, p := range list {
+		var issues []*schema.Issue
+		var err error
+		if strings.Contains(p, "/") {
+			issues, err = searchIssues(p, q)
+		} else {
+			issues, err = search("user:"+p, q)
+		}
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, issues...)
 	}
+	return truncate(all), nil
+}
 
-	var all []*github.Issue
-	for page := 1; ; {
-		// TODO(rsc): Rethink excluding pull requests.
-		x, resp, err := client.Search.Issues(context.TODO(), "type:issue state:open repo:"+project+" "+q, &github.SearchOptions{
-			ListOptions: github.ListOptions{
-				Page:    page,
-				PerPage: 100,
-			},
-		})
-		for i := range x.Issues {
-			updateIssueCache(project, x.Issues[i])
-			all = append(all, x.Issues[i])
-		}
+// searchIssues returns the issues matching q in project.
+func searchIssues(project, q string) ([]*schema.Issue, error) {
+	if filter, order, ok := queryToFilter(project, q); ok {
+		return listRepoIssues(project, filter, order)
+	}
+	return search("repo:"+project, q)
+}
+
+// search returns the issues matching q in scope,
+// a search qualifier such as repo:golang/go or user:golang.
+//
+// GitHub search returns at most 1000 results for any query.
+// When more issues than that match, search divides the query
+// into creation time windows, or update time windows if q already
+// restricts the creation time, each matching at most 1000 issues,
+// and returns the combined results, newest window first.
+func search(scope, q string) ([]*schema.Issue, error) {
+	query := "type:issue " + scope + " " + searchState(q)
+	field := searchSliceField(q)
+	if *maxFlag > 0 && *maxFlag <= searchCap {
+		// No need to look past the first 1000 results.
+		field = ""
+	}
+	all, count, err := searchPages(query, field != "")
+	if err != nil || count <= searchCap || atLimit(all) {
+		return truncate(all), err
+	}
+	if field == "" {
+		// q restricts both times, so it cannot be divided.
+		log.Printf("warning: search matched %d issues, but GitHub returns only the first %d", count, searchCap)
+		return truncate(all), nil
+	}
+
+	seen := make(map[schema.URI]bool)
+	all = nil
+	var searchWindow func(lo, hi time.Time) error
+	searchWindow = func(lo, hi time.Time) error {
+		window := fmt.Sprintf("%s %s:%s..%s", query, field, lo.Format(time.RFC3339), hi.Format(time.RFC3339))
+		split := hi.Sub(lo) >= 2*time.Second
+		list, count, err := searchPages(window, split)
 		if err != nil {
-			return all, err
+			return err
 		}
-		if resp.NextPage < page {
-			break
+		if count > searchCap && split {
+			mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+			if err := searchWindow(mid.Add(time.Second), hi); err != nil || atLimit(all) {
+				return err
+			}
+			return searchWindow(lo, mid)
 		}
-		page = resp.NextPage
+		if count > searchCap {
+			log.Printf("warning: search matched %d issues %s at %s, but GitHub returns only the first %d", count, field, lo.Format(time.RFC3339), searchCap)
+		}
+		for _, issue := range list {
+			// An issue updated during the search can appear in two update windows.
+			if !seen[issue.Url] {
+				seen[issue.Url] = true
+				all = append(all, issue)
+			}
+		}
+		return nil
 	}
-	return all, nil
+	err = searchWindow(searchEpoch, time.Now().UTC().Truncate(time.Second))
+	return truncate(all), err
 }
 
-func queryToListOptions(project, q string) (opt github.IssueListByRepoOptions, ok bool) {
+// searchCap is the maximum number of results GitHub returns for a search.
+const searchCap = 1000
+
+// searchEpoch is a time before any GitHub issue was created or updated.
+var searchEpoch = time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// searchSliceField returns the search qualifier, created or updated,
+// that search can use to divide the query q into time windows,
+// or "" if q restricts both.
+func searchSliceField(q string) string {
+	for _, field := range []string{"created", "updated"} {
+		if !strings.Contains(q, field+":") {
+			return field
+		}
+	}
+	return ""
+}
+
+// searchPages returns the issues matching the search query,
+// along with the total number of matching issues,
+// which may exceed the number of issues GitHub can return.
+// If split is true and the query matches more issues than that,
+// searchPages stops after the first page, expecting the caller
+// to divide the query into smaller ones.
+func searchPages(query string, split bool) ([]*schema.Issue, int, error) {
+	graphql := `
+	  query($Query: String!, $Cursor: String, $PageSize: Int = 100) {
+	    search(query: $Query, type: ISSUE, first: $PageSize, after: $Cursor) {
+	      issueCount
+	      pageInfo { hasNextPage endCursor }
+	      nodes {
+	        __typename
+	        ... on Issue {
+	          ` + issueFields + `
+	        }
+	      }
+	    }
+	  }
+	`
+
+	// TODO(rsc): Rethink excluding pull requests.
+	vars := github.Vars{"Query": query}
+	setPageSize(vars)
+	var all []*schema.Issue
+	count := 0
+	err := queryPages(graphql, vars, func(q *schema.Query) *schema.PageInfo {
+		if q.Search == nil {
+			return nil
+		}
+		count = q.Search.IssueCount
+		for _, node := range q.Search.Nodes {
+			if issue, ok := node.Interface.(*schema.Issue); ok {
+				updateIssueCache(issueProject(issue), issue)
+				all = append(all, issue)
+			}
+		}
+		if atLimit(all) || split && count > searchCap {
+			return nil
+		}
+		return q.Search.PageInfo
+	})
+	return all, count, err
+}
+
+// searchState returns the search query q limited to open issues,
+// unless q already specifies a state. GitHub search has no
+// qualifier for issues in any state, so searchState interprets
+// state:all as a request to leave the state unrestricted,
+// deleting it from the query.
+func searchState(q string) string {
+	fields := strings.Fields(q)
+	for i, f := range fields {
+		switch f {
+		case "state:all":
+			return strings.Join(slices.Delete(fields, i, i+1), " ")
+		case "state:open", "state:closed", "is:open", "is:closed":
+			return q
+		}
+	}
+	return "state:open " + q
+}
+
+// queryToFilter converts the search query q into an equivalent
+// filter and order for listing the issues in project, if possible.
+// Listing is not subject to the limits that GitHub places on search results.
+func queryToFilter(project, q string) (filter *schema.IssueFilters, order *schema.IssueOrder, ok bool) {
 	if strings.ContainsAny(q, `"'`) {
 		return
 	}
+	filter = new(schema.IssueFilters)
+	state := ""
 	for _, f := range strings.Fields(q) {
 		i := strings.Index(f, ":")
 		if i < 0 {
@@ -572,119 +1770,213 @@ func queryToListOptions(project, q string) (opt github.IssueListByRepoOptions, o
 		default:
 			return
 		case "milestone":
-			if opt.Milestone != "" || val == "" {
+			if filter.MilestoneNumber != "" || val == "" {
 				return
 			}
-			id := findMilestone(ioutil.Discard, project, &val)
-			if id == nil {
+			m := findMilestone(ioutil.Discard, project, &val)
+			if m == nil {
 				return
 			}
-			opt.Milestone = fmt.Sprint(*id)
+			filter.MilestoneNumber = fmt.Sprint(m.Number)
 		case "state":
-			if opt.State != "" || val == "" {
+			if state != "" || val == "" {
 				return
 			}
-			opt.State = val
+			state = val
 		case "assignee":
-			if opt.Assignee != "" || val == "" {
+			if filter.Assignee != "" || val == "" {
 				return
 			}
-			opt.Assignee = val
+			filter.Assignee = val
 		case "author":
-			if opt.Creator != "" || val == "" {
+			if filter.CreatedBy != "" || val == "" {
 				return
 			}
-			opt.Creator = val
+			filter.CreatedBy = val
 		case "mentions":
-			if opt.Mentioned != "" || val == "" {
+			if filter.Mentioned != "" || val == "" {
 				return
 			}
-			opt.Mentioned = val
+			filter.Mentioned = val
 		case "label":
-			if opt.Labels != nil || val == "" {
+			if filter.Labels != nil || val == "" {
 				return
 			}
-			opt.Labels = strings.Split(val, ",")
+			filter.Labels = strings.Split(val, ",")
 		case "sort":
-			if opt.Sort != "" || val == "" {
+			if order != nil || val == "" {
 				return
 			}
-			opt.Sort = val
+			order = &schema.IssueOrder{Direction: schema.OrderDirection_DESC}
+			switch val {
+			default:
+				return
+			case "created":
+				order.Field = schema.IssueOrderField_CREATED_AT
+			case "updated":
+				order.Field = schema.IssueOrderField_UPDATED_AT
+			case "comments":
+				order.Field = schema.IssueOrderField_COMMENTS
+			}
 		case "updated":
-			if !opt.Since.IsZero() || !strings.HasPrefix(val, ">=") {
+			if filter.Since != "" || !strings.HasPrefix(val, ">=") {
 				return
 			}
-			// TODO: Can set Since if we parse val[2:].
-			return
+			t, err := parseTime(val[2:])
+			if err != nil {
+				return
+			}
+			filter.Since = schema.DateTime(t.UTC().Format(time.RFC3339))
 		case "no":
 			switch val {
 			default:
 				return
 			case "milestone":
-				if opt.Milestone != "" {
+				if filter.MilestoneNumber != "" {
 					return
 				}
-				opt.Milestone = "none"
+				filter.MilestoneNumber = "none"
 			}
 		}
 	}
-	return opt, true
+	switch state {
+	default:
+		return
+	case "", "open":
+		filter.States = []schema.IssueState{schema.IssueState_OPEN}
+	case "closed":
+		filter.States = []schema.IssueState{schema.IssueState_CLOSED}
+	case "all":
+		// no filter
+	}
+	return filter, order, true
 }
 
-func listRepoIssues(project string, opt github.IssueListByRepoOptions) ([]*github.Issue, error) {
-	var all []*github.Issue
-	for page := 1; ; {
-		xopt := opt
-		xopt.ListOptions = github.ListOptions{
-			Page:    page,
-			PerPage: 100,
-		}
-		issues, resp, err := client.Issues.ListByRepo(context.TODO(), projectOwner(project), projectRepo(project), &xopt)
-		for i := range issues {
-			updateIssueCache(project, issues[i])
-			all = append(all, issues[i])
+// parseSince parses the -since flag value s,
+// either a duration before now or a time accepted by parseTime.
+func parseSince(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	t, err := parseTime(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid -since %q: must be duration like 24h or time like 2024-01-02", s)
+	}
+	return t, nil
+}
+
+// parseTime parses s, which must be a date like 2024-01-02
+// or an RFC 3339 time like 2024-01-02T15:04:05Z,
+// as used in GitHub search qualifiers.
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func listRepoIssues(project string, filter *schema.IssueFilters, order *schema.IssueOrder) ([]*schema.Issue, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Filter: IssueFilters, $Order: IssueOrder, $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      issues(first: $PageSize, after: $Cursor, filterBy: $Filter, orderBy: $Order) {
+	        pageInfo { hasNextPage endCursor }
+	        nodes {
+	          ` + issueFields + `
+	        }
+	      }
+	    }
+	  }
+	`
+
+	// Unlike searchIssues, there is no need to exclude pull requests:
+	// a repository's issues connection lists only issues.
+	vars := github.Vars{"Org": projectOwner(project), "Repo": projectRepo(project), "Filter": filter, "Order": order}
+	setPageSize(vars)
+	var all []*schema.Issue
+	err := queryPages(graphql, vars, func(q *schema.Query) *schema.PageInfo {
+		if q.Repository == nil || q.Repository.Issues == nil {
+			return nil
 		}
-		if err != nil {
-			return all, err
+		for _, issue := range q.Repository.Issues.Nodes {
+			updateIssueCache(project, issue)
+			all = append(all, issue)
 		}
-		if resp.NextPage < page {
-			break
+		if atLimit(all) {
+			return nil
 		}
-		page = resp.NextPage
+		return q.Repository.Issues.PageInfo
+	})
+	return truncate(all), err
+}
+
+// setPageSize sets the $PageSize in vars to avoid fetching
+// many more results than the -n flag allows.
+func setPageSize(vars github.Vars) {
+	if *maxFlag > 0 && *maxFlag < 100 {
+		vars["PageSize"] = *maxFlag
 	}
+}
 
-	// Filter out pull requests, since we cannot say type:issue like in searchIssues.
-	// TODO(rsc): Rethink excluding pull requests.
-	save := all[:0]
-	for _, issue := range all {
-		if issue.PullRequestLinks == nil {
-			save = append(save, issue)
-		}
+// atLimit reports whether all has as many results as the -n flag allows.
+func atLimit(all []*schema.Issue) bool {
+	return *maxFlag > 0 && len(all) >= *maxFlag
+}
+
+// truncate returns all truncated to the number of results the -n flag allows.
+func truncate(all []*schema.Issue) []*schema.Issue {
+	if atLimit(all) {
+		all = all[:*maxFlag]
 	}
-	return save, nil
+	return all
 }
 
-func loadMilestones(project string) ([]*github.Milestone, error) {
-	// NOTE(rsc): There appears to be no paging possible.
-	all, _, err := client.Issues.ListMilestones(context.TODO(), projectOwner(project), projectRepo(project), &github.MilestoneListOptions{
-		State: "open",
+// loadMilestones returns the open milestones in project, sorted by due date.
+func loadMilestones(project string) ([]*schema.Milestone, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Cursor: String) {
+	    repository(owner: $Org, name: $Repo) {
+	      milestones(first: 100, after: $Cursor, states: [OPEN], orderBy: {field: DUE_DATE, direction: ASC}) {
+	        pageInfo { hasNextPage endCursor }
+	        nodes {
+	          id
+	          number
+	          title
+	          dueOn
+	          issues(states: [OPEN]) { totalCount }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	vars := github.Vars{"Org": projectOwner(project), "Repo": projectRepo(project)}
+	all := []*schema.Milestone{}
+	err := queryPages(graphql, vars, func(q *schema.Query) *schema.PageInfo {
+		if q.Repository == nil || q.Repository.Milestones == nil {
+			return nil
+		}
+		all = append(all, q.Repository.Milestones.Nodes...)
+		return q.Repository.Milestones.PageInfo
 	})
 	if err != nil {
 		return nil, err
 	}
-	if all == nil {
-		all = []*github.Milestone{}
-	}
 	return all, nil
 }
 
+// wrapWidth returns the column at which to wrap text.
+func wrapWidth() int {
+	if *acmeFlag {
+		return 120
+	}
+	return 70
+}
+
 func wrap(t string, prefix string) string {
 	out := ""
 	t = strings.Replace(t, "\r\n", "\n", -1)
-	max := 70
-	if *acmeFlag {
-		max = 120
-	}
+	max := wrapWidth()
 	lines := strings.Split(t, "\n")
 	for i, line := range lines {
 		if i > 0 {
@@ -707,85 +1999,169 @@ func wrap(t string, prefix string) string {
 
 var client *github.Client
 
-// GitHub personal access token, from https://github.com/settings/applications.
-var authToken string
-
+// loadAuth sets client, using the GitHub personal access token
+// (from https://github.com/settings/tokens) in the -token file.
+// If there is no -token flag and no $HOME/.github-issue-token file,
+// loadAuth uses the credentials found by [github.DialEnterprise]
+// for the -host flag. With -keychain, loadAuth reads the token
+// from the system keychain instead.
 func loadAuth() {
-	const short = ".github-issue-token"
-	filename := filepath.Clean(os.Getenv("HOME") + "/" + short)
-	shortFilename := filepath.Clean("$HOME/" + short)
-	if *tokenFile != "" {
-		filename = *tokenFile
-		shortFilename = *tokenFile
+	filename, shortFilename := tokenFilename()
+	if *keychain {
+		token, err := keychainToken(filepath.Base(filename))
+		if err != nil {
+			fatalf("reading token from keychain: %v\n\n"+
+				"To copy the token in %s to the keychain, run 'issue -keychain import'.", err, shortFilename)
+		}
+		client = github.NewEnterpriseClient(*hostFlag, token)
+		return
 	}
-	data, err := ioutil.ReadFile(filename)
+	data, err := readTokenFile(filename, shortFilename)
 	if err != nil {
-		log.Fatal("reading token: ", err, "\n\n"+
-			"Please create a personal access token at https://github.com/settings/tokens/new\n"+
+		if *tokenFile == "" && *profile == "" && os.IsNotExist(err) {
+			c, dialErr := github.DialEnterprise(*hostFlag, "")
+			if dialErr == nil {
+				client = c
+				return
+			}
+		}
+		fatal("reading token: ", err, "\n\n"+
+			"Please create a personal access token at https://"+*hostFlag+"/settings/tokens/new\n"+
 			"and write it to ", shortFilename, " to use this program.\n"+
 			"The token only needs the repo scope, or private_repo if you want to\n"+
 			"view or edit issues for private repositories.\n"+
 			"The benefit of using a personal access token over using your GitHub\n"+
-			"password directly is that you can limit its use and revoke it at any time.\n\n")
+			"password directly is that you can limit its use and revoke it at any time.\n"+
+			"Alternatively, log in using the gh command (gh auth login).\n\n")
 	}
-	fi, err := os.Stat(filename)
+	client = github.NewEnterpriseClient(*hostFlag, data)
+}
+
+// importToken copies the token from the token file to the system keychain.
+func importToken() {
+	filename, shortFilename := tokenFilename()
+	token, err := readTokenFile(filename, shortFilename)
 	if err != nil {
-		log.Fatal(err)
-	} else if fi.Mode()&0077 != 0 {
-		log.Fatalf("reading token: %s mode is %#o, want %#o", shortFilename, fi.Mode()&0777, fi.Mode()&0700)
+		fatal("reading token: ", err)
+	}
+	if token == "" {
+		fatalf("reading token: %s is empty", shortFilename)
 	}
-	authToken = strings.TrimSpace(string(data))
-	t := &oauth2.Transport{
-		Source: &tokenSource{AccessToken: authToken},
+	if err := setKeychainToken(filepath.Base(filename), token); err != nil {
+		fatal("writing token to keychain: ", err)
 	}
-	client = github.NewClient(&http.Client{Transport: t})
+	fmt.Fprintf(os.Stderr, "copied token from %s to keychain; use -keychain to read it, and delete %s\n", shortFilename, shortFilename)
 }
 
-type tokenSource oauth2.Token
+// tokenFilename returns the name of the file holding the GitHub token,
+// along with a shorter form for use in messages, as in $HOME/.github-issue-token.
+func tokenFilename() (filename, shortFilename string) {
+	if *tokenFile != "" && *profile != "" {
+		fatal("cannot use -token with -profile")
+	}
+	short := ".github-issue-token"
+	if *profile != "" {
+		short += "-" + *profile
+	} else if *tokenFile == "" {
+		owner := projectsOwner()
+		if _, err := os.Stat(filepath.Join(os.Getenv("HOME"), short+"-"+owner)); owner != "" && err == nil {
+			short += "-" + owner
+		} else if *hostFlag != "github.com" {
+			short += "-" + *hostFlag
+		}
+	}
+	if *tokenFile != "" {
+		return *tokenFile, *tokenFile
+	}
+	return filepath.Clean(os.Getenv("HOME") + "/" + short), filepath.Clean("$HOME/" + short)
+}
 
-func (t *tokenSource) Token() (*oauth2.Token, error) {
-	return (*oauth2.Token)(t), nil
+// readTokenFile returns the token in filename,
+// after checking that the file is not readable by other users.
+func readTokenFile(filename, shortFilename string) (string, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	fi, err := os.Stat(filename)
+	if err != nil {
+		fatal(err)
+	} else if fi.Mode()&0077 != 0 {
+		fatalf("reading token: %s mode is %#o, want %#o", shortFilename, fi.Mode()&0777, fi.Mode()&0700)
+	}
+	return strings.TrimSpace(string(data)), nil
 }
 
-func getInt(x *int) int {
-	if x == nil {
-		return 0
+// projectsOwner returns the owner shared by all the -p projects,
+// or the empty string if they have different owners.
+func projectsOwner() string {
+	owner := ""
+	for _, p := range projects {
+		o, _, _ := strings.Cut(p, "/")
+		if owner != "" && o != owner {
+			return ""
+		}
+		owner = o
 	}
-	return *x
+	return owner
 }
 
-func getString(x *string) string {
-	if x == nil {
+func getUserLogin(x schema.Actor) string {
+	if x.Interface == nil {
 		return ""
 	}
-	return *x
+	return x.Interface.GetLogin()
 }
 
-func getUserLogin(x *github.User) string {
-	if x == nil || x.Login == nil {
+func getAssigneeLogin(x schema.Assignee) string {
+	if a, ok := x.Interface.(interface{ GetLogin() string }); ok {
+		return a.GetLogin()
+	}
+	return ""
+}
+
+// getAssignee returns the login of the first user in x.
+func getAssignee(x *schema.UserConnection) string {
+	if x == nil || len(x.Nodes) == 0 {
 		return ""
 	}
-	return *x.Login
+	return x.Nodes[0].Login
 }
 
-func getTime(x *github.Timestamp) time.Time {
-	if x == nil {
+// getState returns the issue state as "open" or "closed".
+func getState(issue *schema.Issue) string {
+	return strings.ToLower(string(issue.State))
+}
+
+func getTime(x schema.DateTime) time.Time {
+	t, err := time.Parse(time.RFC3339, string(x))
+	if err != nil {
 		return time.Time{}
 	}
-	return x.Local()
+	return t.Local()
+}
+
+func getMilestoneTitle(x *schema.Milestone) string {
+	if x == nil {
+		return ""
+	}
+	return x.Title
 }
 
-func getMilestoneTitle(x *github.Milestone) string {
-	if x == nil || x.Title == nil {
+func getLabelName(x *schema.Label) string {
+	if x == nil {
 		return ""
 	}
-	return *x.Title
+	return x.Name
 }
 
-func getLabelNames(x []*github.Label) []string {
+func getLabelNames(x *schema.LabelConnection) []string {
+	if x == nil {
+		return nil
+	}
 	var out []string
-	for _, lab := range x {
-		out = append(out, getString(lab.Name))
+	for _, lab := range x.Nodes {
+		out = append(out, lab.Name)
 	}
 	sort.Strings(out)
 	return out
@@ -798,45 +2174,50 @@ type projectAndNumber struct {
 
 var issueCache struct {
 	sync.Mutex
-	m map[projectAndNumber]*github.Issue
+	m map[projectAndNumber]*schema.Issue
 }
 
-func updateIssueCache(project string, issue *github.Issue) {
-	n := getInt(issue.Number)
+func updateIssueCache(project string, issue *schema.Issue) {
+	n := issue.Number
 	if n == 0 {
 		return
 	}
 	issueCache.Lock()
 	if issueCache.m == nil {
-		issueCache.m = make(map[projectAndNumber]*github.Issue)
+		issueCache.m = make(map[projectAndNumber]*schema.Issue)
 	}
 	issueCache.m[projectAndNumber{project, n}] = issue
 	issueCache.Unlock()
 }
 
-func bulkReadIssuesCached(project string, ids []int) ([]*github.Issue, error) {
-	var all []*github.Issue
+func bulkReadIssuesCached(project string, ids []int) ([]*schema.Issue, error) {
+	var all []*schema.Issue
 	issueCache.Lock()
 	for _, id := range ids {
 		all = append(all, issueCache.m[projectAndNumber{project, id}])
 	}
 	issueCache.Unlock()
 
-	var errbuf bytes.Buffer
+	// Fetch the issues missing from the cache together.
+	var missing []int
 	for i, id := range ids {
 		if all[i] == nil {
-			issue, _, err := client.Issues.Get(context.TODO(), projectOwner(project), projectRepo(project), id)
-			if err != nil {
-				fmt.Fprintf(&errbuf, "reading #%d: %v\n", id, err)
-				continue
-			}
-			updateIssueCache(project, issue)
-			all[i] = issue
+			missing = append(missing, id)
 		}
 	}
-	var err error
-	if errbuf.Len() > 0 {
-		err = fmt.Errorf("%s", strings.TrimSpace(errbuf.String()))
+	if len(missing) == 0 {
+		return all, nil
+	}
+	issues, err := getIssues(project, missing)
+	j := 0
+	for i := range all {
+		if all[i] == nil {
+			if issue := issues[j]; issue != nil {
+				updateIssueCache(project, issue)
+				all[i] = issue
+			}
+			j++
+		}
 	}
 	return all, err
 }
@@ -859,6 +2240,14 @@ type Issue struct {
 	Text      string
 	Comments  []*Comment
 	Reactions Reactions
+
+	Projects    []*Project   `json:",omitempty"`
+	PullRequest *PullRequest `json:",omitempty"`
+}
+
+type Project struct {
+	Title  string
+	Status string
 }
 
 type Comment struct {
@@ -879,44 +2268,63 @@ type Reactions struct {
 	Eyes     int
 }
 
-func showJSONIssue(w io.Writer, project string, issue *github.Issue) {
-	data, err := json.MarshalIndent(toJSONWithComments(project, issue), "", "\t")
+type PullRequest struct {
+	Review    string
+	Reviews   []*Review
+	Reviewers []string
+	Checks    string
+	Files     []*File
+}
+
+type Review struct {
+	Author string
+	State  string
+}
+
+type File struct {
+	Path      string
+	Additions int
+	Deletions int
+}
+
+func showJSONIssue(w io.Writer, project string, issue *schema.Issue, pr *schema.PullRequest, timeline []any) {
+	data, err := json.MarshalIndent(toJSONDetail(project, issue, pr, timeline), "", "\t")
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	data = append(data, '\n')
 	w.Write(data)
 }
 
-func showJSONList(project string, all []*github.Issue) {
+func showJSONList(all []*schema.Issue) {
 	j := []*Issue{} // non-nil for json
 	for _, issue := range all {
-		j = append(j, toJSON(project, issue))
+		j = append(j, toJSON(issueProject(issue), issue))
 	}
 	data, err := json.MarshalIndent(j, "", "\t")
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	data = append(data, '\n')
 	os.Stdout.Write(data)
 }
 
-func toJSON(project string, issue *github.Issue) *Issue {
+func toJSON(project string, issue *schema.Issue) *Issue {
 	j := &Issue{
-		Number:    getInt(issue.Number),
-		Ref:       fmt.Sprintf("%s/%s#%d\n", projectOwner(project), projectRepo(project), getInt(issue.Number)),
-		Title:     getString(issue.Title),
-		State:     getString(issue.State),
-		Assignee:  getUserLogin(issue.Assignee),
+		Number:    issue.Number,
+		Ref:       fmt.Sprintf("%s/%s#%d", projectOwner(project), projectRepo(project), issue.Number),
+		Title:     issue.Title,
+		State:     getState(issue),
+		Assignee:  getAssignee(issue.Assignees),
 		Closed:    getTime(issue.ClosedAt),
 		Labels:    getLabelNames(issue.Labels),
 		Milestone: getMilestoneTitle(issue.Milestone),
-		URL:       fmt.Sprintf("https://github.com/%s/%s/issues/%d\n", projectOwner(project), projectRepo(project), getInt(issue.Number)),
-		Reporter:  getUserLogin(issue.User),
+		URL:       fmt.Sprintf("https://%s/%s/%s/issues/%d", *hostFlag, projectOwner(project), projectRepo(project), issue.Number),
+		Reporter:  getUserLogin(issue.Author),
 		Created:   getTime(issue.CreatedAt),
-		Text:      getString(issue.Body),
+		Text:      issue.Body,
 		Comments:  []*Comment{},
-		Reactions: getReactions(issue.Reactions),
+		Reactions: getReactions(issue.ReactionGroups),
 	}
 	if j.Labels == nil {
 		j.Labels = []string{}
@@ -924,34 +2332,134 @@ func toJSON(project string, issue *github.Issue) *Issue {
 	return j
 }
 
-func toJSONWithComments(project string, issue *github.Issue) *Issue {
+func toJSONWithComments(project string, issue *schema.Issue, timeline []any) *Issue {
 	j := toJSON(project, issue)
-	for page := 1; ; {
-		list, resp, err := client.Issues.ListComments(context.TODO(), projectOwner(project), projectRepo(project), getInt(issue.Number), &github.IssueListCommentsOptions{
-			ListOptions: github.ListOptions{
-				Page:    page,
-				PerPage: 100,
-			},
-		})
-		if err != nil {
-			log.Fatal(err)
-		}
-		for _, com := range list {
+	for _, item := range timeline {
+		if com, ok := item.(*schema.IssueComment); ok {
 			j.Comments = append(j.Comments, &Comment{
-				Author:    getUserLogin(com.User),
+				Author:    getUserLogin(com.Author),
 				Time:      getTime(com.CreatedAt),
-				Text:      getString(com.Body),
-				Reactions: getReactions(com.Reactions),
+				Text:      com.Body,
+				Reactions: getReactions(com.ReactionGroups),
 			})
 		}
-		if resp.NextPage < page {
-			break
+	}
+	return j
+}
+
+// toJSONDetail returns the JSON form of a single issue:
+// the issue with its comments and, for a pull request, the pull request details.
+func toJSONDetail(project string, issue *schema.Issue, pr *schema.PullRequest, timeline []any) *Issue {
+	j := toJSONWithComments(project, issue, timeline)
+	for _, item := range z(issue.ProjectItems).Nodes {
+		j.Projects = append(j.Projects, &Project{Title: z(item.Project).Title, Status: getProjectStatus(item)})
+	}
+	if pr != nil {
+		j.PullRequest = toJSONPullRequest(pr)
+	}
+	return j
+}
+
+func toJSONPullRequest(pr *schema.PullRequest) *PullRequest {
+	j := &PullRequest{
+		Review:    string(pr.ReviewDecision),
+		Reviews:   []*Review{},
+		Reviewers: []string{},
+		Files:     []*File{},
+	}
+	if pr.LatestReviews != nil {
+		for _, r := range pr.LatestReviews.Nodes {
+			j.Reviews = append(j.Reviews, &Review{Author: getUserLogin(r.Author), State: string(r.State)})
+		}
+	}
+	if pr.ReviewRequests != nil {
+		for _, r := range pr.ReviewRequests.Nodes {
+			switch x := r.RequestedReviewer.Interface.(type) {
+			case *schema.User:
+				j.Reviewers = append(j.Reviewers, x.Login)
+			case *schema.Mannequin:
+				j.Reviewers = append(j.Reviewers, x.Login)
+			case *schema.Team:
+				j.Reviewers = append(j.Reviewers, x.CombinedSlug)
+			}
+		}
+	}
+	if pr.Commits != nil && len(pr.Commits.Nodes) > 0 {
+		if c := pr.Commits.Nodes[0].Commit; c != nil && c.StatusCheckRollup != nil {
+			j.Checks = string(c.StatusCheckRollup.State)
+		}
+	}
+	if pr.Files != nil {
+		for _, f := range pr.Files.Nodes {
+			j.Files = append(j.Files, &File{Path: f.Path, Additions: f.Additions, Deletions: f.Deletions})
 		}
-		page = resp.NextPage
 	}
 	return j
 }
 
+// formatTmpl is the template from the -format flag, if any.
+var formatTmpl *template.Template
+
+// showFormat writes j to w, formatted using formatTmpl, followed by a newline.
+func showFormat(w io.Writer, j *Issue) error {
+	if err := formatTmpl.Execute(w, j); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n")
+	return err
+}
+
+// csvFields maps each -csv column name to a function returning that column for an issue.
+var csvFields = map[string]func(*schema.Issue) string{
+	"repo":      func(issue *schema.Issue) string { return issueProject(issue) },
+	"number":    func(issue *schema.Issue) string { return fmt.Sprint(issue.Number) },
+	"title":     func(issue *schema.Issue) string { return issue.Title },
+	"labels":    func(issue *schema.Issue) string { return strings.Join(getLabelNames(issue.Labels), " ") },
+	"milestone": func(issue *schema.Issue) string { return getMilestoneTitle(issue.Milestone) },
+	"assignee":  func(issue *schema.Issue) string { return getAssignee(issue.Assignees) },
+	"created":   func(issue *schema.Issue) string { return getTime(issue.CreatedAt).Format(timeFormat) },
+	"updated":   func(issue *schema.Issue) string { return getTime(issue.UpdatedAt).Format(timeFormat) },
+}
+
+// allCSVColumns is the column list for -csv all.
+const allCSVColumns = "repo,number,title,labels,milestone,assignee,created,updated"
+
+var (
+	csvColumns []string // columns from the -csv or -tsv flag, if any
+	csvComma   = ','    // column separator
+)
+
+// parseCSVColumns parses the comma-separated column list cols into csvColumns.
+func parseCSVColumns(cols string) error {
+	if cols == "all" {
+		cols = allCSVColumns
+	}
+	for _, col := range strings.Split(cols, ",") {
+		col = strings.TrimSpace(col)
+		if csvFields[col] == nil {
+			return fmt.Errorf("unknown CSV column %q (known columns are %s)", col, allCSVColumns)
+		}
+		csvColumns = append(csvColumns, col)
+	}
+	return nil
+}
+
+// showCSV writes the csvColumns of each issue in all to w, after a header row.
+func showCSV(w io.Writer, all []*schema.Issue) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = csvComma
+	cw.Write(csvColumns)
+	for _, issue := range all {
+		var row []string
+		for _, col := range csvColumns {
+			row = append(row, csvFields[col](issue))
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
 func (r Reactions) String() string {
 	var buf bytes.Buffer
 	add := func(s string, n int) {
@@ -973,20 +2481,48 @@ func (r Reactions) String() string {
 	return buf.String()
 }
 
-func getReactions(r *github.Reactions) Reactions {
-	if r == nil {
-		return Reactions{}
+// getCreatedAt returns the creation time of the timeline item,
+// or the empty string if it has none.
+func getCreatedAt(item any) schema.DateTime {
+	if x, ok := item.(interface{ GetCreatedAt() schema.DateTime }); ok {
+		return x.GetCreatedAt()
 	}
-	return Reactions{
-		PlusOne:  z(r.PlusOne),
-		MinusOne: z(r.MinusOne),
-		Laugh:    z(r.Laugh),
-		Confused: z(r.Confused),
-		Heart:    z(r.Heart),
-		Hooray:   z(r.Hooray),
-		Rocket:   z(r.Rocket),
-		Eyes:     z(r.Eyes),
+	return ""
+}
+
+// getProjectStatus returns the value of the Status field for the project item,
+// or the empty string if there is none.
+func getProjectStatus(item *schema.ProjectV2Item) string {
+	if v, ok := item.FieldValueByName.Interface.(*schema.ProjectV2ItemFieldSingleSelectValue); ok {
+		return v.Name
+	}
+	return ""
+}
+
+func getReactions(groups []*schema.ReactionGroup) Reactions {
+	var r Reactions
+	for _, g := range groups {
+		n := z(g.Reactors).TotalCount
+		switch g.Content {
+		case schema.ReactionContent_THUMBS_UP:
+			r.PlusOne = n
+		case schema.ReactionContent_THUMBS_DOWN:
+			r.MinusOne = n
+		case schema.ReactionContent_LAUGH:
+			r.Laugh = n
+		case schema.ReactionContent_CONFUSED:
+			r.Confused = n
+		case schema.ReactionContent_HEART:
+			r.Heart = n
+		case schema.ReactionContent_HOORAY:
+			r.Hooray = n
+		case schema.ReactionContent_ROCKET:
+			r.Rocket = n
+		case schema.ReactionContent_EYES:
+			r.Eyes = n
+		}
 	}
+	return r
 }
 
 func z[T any](x *T) T {
@@ -0,0 +1,35 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"rsc.io/github/githubtest"
+)
+
+func TestBulkReadIssues(t *testing.T) {
+	// The three issues are fetched in a single request.
+	// Issue 2 does not exist; the others must still be returned.
+	old := client
+	defer func() { client = old }()
+	client = githubtest.Client(t, "testdata/bulkread.json")
+
+	issues, err := bulkReadIssuesCached("rsc/quote", []int{1, 2, 3})
+	if err == nil || !strings.Contains(err.Error(), "rsc/quote#2") || strings.Contains(err.Error(), "#1") || strings.Contains(err.Error(), "#3") {
+		t.Errorf("bulkReadIssuesCached: err = %v, want error for #2 only", err)
+	}
+	if len(issues) != 3 || issues[0] == nil || issues[0].Title != "first" || issues[1] != nil || issues[2] == nil || issues[2].Title != "third" {
+		t.Fatalf("bulkReadIssuesCached = %v, want [first nil third]", issues)
+	}
+
+	// The issues found are cached, so reading them again
+	// sends no requests; the recording has only one.
+	issues, err = bulkReadIssuesCached("rsc/quote", []int{3, 1})
+	if err != nil || len(issues) != 2 || issues[0].Title != "third" || issues[1].Title != "first" {
+		t.Errorf("cached bulkReadIssuesCached = %v, %v, want [third first]", issues, err)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keychainService is the service name for tokens stored in the system keychain.
+const keychainService = "rsc.io/github/issue"
+
+// keychainToken returns the token stored in the system keychain
+// under the given account name.
+// On macOS it uses the security command; elsewhere it uses
+// secret-tool, the command-line interface to libsecret.
+func keychainToken(account string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "darwin" {
+		cmd = exec.Command("security", "find-generic-password", "-s", keychainService, "-a", account, "-w")
+	} else {
+		cmd = exec.Command("secret-tool", "lookup", "service", keychainService, "account", account)
+	}
+	out, err := runKeychain(cmd)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(out))
+	if token == "" {
+		return "", fmt.Errorf("no token for %s in keychain", account)
+	}
+	return token, nil
+}
+
+// setKeychainToken stores token in the system keychain
+// under the given account name, replacing any existing token.
+func setKeychainToken(account, token string) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "darwin" {
+		// The security command only accepts the password as an argument,
+		// briefly exposing it to other local users' process listings.
+		// That is still better than leaving it in a file indefinitely.
+		cmd = exec.Command("security", "add-generic-password", "-U", "-s", keychainService, "-a", account, "-w", token)
+	} else {
+		cmd = exec.Command("secret-tool", "store", "--label=GitHub token for issue ("+account+")", "service", keychainService, "account", account)
+		cmd.Stdin = strings.NewReader(token)
+	}
+	_, err := runKeychain(cmd)
+	return err
+}
+
+// runKeychain runs cmd and returns its standard output,
+// including its standard error in any error.
+func runKeychain(cmd *exec.Cmd) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if stderr.Len() > 0 {
+			err = fmt.Errorf("%v\n%s", err, bytes.TrimSpace(stderr.Bytes()))
+		}
+		return nil, fmt.Errorf("%s: %v", strings.Join(cmd.Args[:2], " "), err)
+	}
+	return out, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// keychainService is the prefix of the target names
+// for tokens stored in the Windows Credential Manager.
+const keychainService = "rsc.io/github/issue"
+
+var (
+	advapi32   = syscall.NewLazyDLL("advapi32.dll")
+	credReadW  = advapi32.NewProc("CredReadW")
+	credWriteW = advapi32.NewProc("CredWriteW")
+	credFree   = advapi32.NewProc("CredFree")
+)
+
+// A credential is a Windows CREDENTIALW structure.
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+)
+
+// keychainToken returns the token stored in the Windows Credential Manager
+// under the given account name.
+func keychainToken(account string) (string, error) {
+	target, err := syscall.UTF16PtrFromString(keychainService + ":" + account)
+	if err != nil {
+		return "", err
+	}
+	var cred *credential
+	ok, _, err := credReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ok == 0 {
+		return "", fmt.Errorf("CredRead %s: %v", account, err)
+	}
+	defer credFree.Call(uintptr(unsafe.Pointer(cred)))
+	return string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), nil
+}
+
+// setKeychainToken stores token in the Windows Credential Manager
+// under the given account name, replacing any existing token.
+func setKeychainToken(account, token string) error {
+	target, err := syscall.UTF16PtrFromString(keychainService + ":" + account)
+	if err != nil {
+		return err
+	}
+	user, err := syscall.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+	blob := []byte(token)
+	cred := &credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(blob)),
+		CredentialBlob:     &blob[0],
+		Persist:            credPersistLocalMachine,
+		UserName:           user,
+	}
+	ok, _, err := credWriteW.Call(uintptr(unsafe.Pointer(cred)), 0)
+	if ok == 0 {
+		return fmt.Errorf("CredWrite %s: %v", account, err)
+	}
+	return nil
+}
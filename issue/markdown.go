@@ -0,0 +1,159 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	headingRE = regexp.MustCompile(`^ {0,3}#{1,6}(\s|$)`)
+	quoteRE   = regexp.MustCompile(`^ {0,3}>`)
+	listRE    = regexp.MustCompile(`^(\s*(?:[-*+]|\d{1,9}[.)])\s+)(.*)$`)
+	linkRE    = regexp.MustCompile(`(!?)\[([^\]]*)\]\(([^()\s]+)\)`)
+)
+
+// render formats the markdown text t for display,
+// inserting prefix at the start of every line after the first.
+// Unlike wrap, render understands enough markdown structure to
+// leave code blocks alone: fenced and indented code is printed verbatim.
+// Paragraph lines are wrapped, list items are wrapped with a hanging indent,
+// block quotes are wrapped with the quote marker repeated on each line,
+// and inline links are replaced by numbered footnotes listed after the text.
+// Indented lines following a list item continue the item
+// rather than starting a code block.
+func render(t, prefix string) string {
+	t = strings.ReplaceAll(t, "\r\n", "\n")
+	max := wrapWidth()
+	var out, links []string
+	fence := ""
+	inList := false
+	for _, line := range strings.Split(t, "\n") {
+		trim := strings.TrimLeft(line, " ")
+		if fence != "" {
+			out = append(out, line)
+			if strings.HasPrefix(trim, fence) && strings.Trim(trim, fence[:1]) == "" {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trim, "```") || strings.HasPrefix(trim, "~~~") {
+			fence = trim[:len(trim)-len(strings.TrimLeft(trim, trim[:1]))]
+			out = append(out, line)
+			continue
+		}
+		indented := strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+		if indented && !inList {
+			out = append(out, line)
+			continue
+		}
+		continued := inList && (indented || trim != line)
+		if trim != "" && !continued {
+			inList = false
+		}
+
+		line = footnoteLinks(line, &links)
+		switch {
+		case headingRE.MatchString(line):
+			for _, l := range wrapLine(strings.TrimSpace(line), "", "", max) {
+				out = append(out, colorize(l, "1"))
+			}
+		case quoteRE.MatchString(line):
+			depth := 0
+			for {
+				line = strings.TrimLeft(line, " ")
+				if !strings.HasPrefix(line, ">") {
+					break
+				}
+				line = line[1:]
+				depth++
+			}
+			lead := strings.Repeat("> ", depth)
+			out = append(out, wrapLine(line, lead, lead, max)...)
+		case listRE.MatchString(line):
+			m := listRE.FindStringSubmatch(line)
+			out = append(out, wrapLine(m[2], m[1], strings.Repeat(" ", len(m[1])), max)...)
+			inList = true
+		case continued:
+			// Continuation of a list item.
+			n := len(line) - len(strings.TrimLeft(line, " \t"))
+			out = append(out, wrapLine(line[n:], line[:n], line[:n], max)...)
+		default:
+			out = append(out, wrapLine(strings.TrimSpace(line), "", "", max)...)
+		}
+	}
+	if len(links) > 0 {
+		out = append(out, "")
+		for i, url := range links {
+			out = append(out, fmt.Sprintf("[%d] %s", i+1, url))
+		}
+	}
+	return strings.Join(out, "\n"+prefix)
+}
+
+// footnoteLinks replaces the inline links and images in line,
+// outside of code spans, with their text followed by a footnote number.
+// It appends newly seen URLs to *links; the footnote number for
+// a URL is its index in *links plus one.
+// A link whose text is its URL is left as the bare URL.
+func footnoteLinks(line string, links *[]string) string {
+	parts := strings.Split(line, "`")
+	for i := 0; i < len(parts); i += 2 {
+		parts[i] = linkRE.ReplaceAllStringFunc(parts[i], func(s string) string {
+			m := linkRE.FindStringSubmatch(s)
+			image, text, url := m[1] != "", m[2], m[3]
+			if text == url {
+				return url
+			}
+			if image {
+				text = "image: " + text
+				if text == "image: " {
+					text = "image"
+				}
+			}
+			n := 0
+			for j, l := range *links {
+				if l == url {
+					n = j + 1
+				}
+			}
+			if n == 0 {
+				*links = append(*links, url)
+				n = len(*links)
+			}
+			return fmt.Sprintf("%s[%d]", text, n)
+		})
+	}
+	return strings.Join(parts, "`")
+}
+
+// wrapLine wraps s to lines of at most max bytes where possible,
+// starting the first line with lead and the rest with indent.
+// Words longer than a line, such as URLs, are never split.
+func wrapLine(s, lead, indent string, max int) []string {
+	var lines []string
+	for {
+		n := max - len(lead)
+		if n < 20 {
+			n = 20
+		}
+		if len(s) <= n {
+			return append(lines, strings.TrimRight(lead+s, " "))
+		}
+		i := strings.LastIndex(s[:n+1], " ")
+		if i <= 0 {
+			i = strings.Index(s[n:], " ")
+			if i < 0 {
+				return append(lines, lead+s)
+			}
+			i += n
+		}
+		lines = append(lines, lead+strings.TrimRight(s[:i], " "))
+		s = strings.TrimLeft(s[i:], " ")
+		lead = indent
+	}
+}
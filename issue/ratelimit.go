@@ -0,0 +1,64 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// showRateLimit implements “issue ratelimit”, which prints
+// the core (REST), search, and GraphQL rate limits for the token,
+// with the number of requests or points remaining and the reset times.
+func showRateLimit(w io.Writer) {
+	type limit struct {
+		Limit     int
+		Remaining int
+		Used      int
+		Reset     int64 // Unix seconds
+	}
+	var reply struct {
+		Resources map[string]*limit
+	}
+	if err := client.REST("GET", "/rate_limit", nil, &reply); err != nil {
+		fatal(err)
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "API\tREMAINING\tLIMIT\tRESET\n")
+	for _, name := range []string{"core", "search", "graphql"} {
+		l := reply.Resources[name]
+		if l == nil {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\n", name, l.Remaining, l.Limit, formatReset(time.Unix(l.Reset, 0)))
+	}
+	tw.Flush()
+}
+
+// printRateSummary prints the GraphQL rate limit as of
+// the most recent request to standard error, for the -v flag.
+func printRateSummary() {
+	if client == nil {
+		return
+	}
+	rl := client.RateLimit()
+	if rl == nil {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "issue: graphql rate limit: %d of %d points remaining, reset %s\n", rl.Remaining, rl.Limit, formatReset(rl.ResetAt))
+}
+
+// formatReset formats the rate limit reset time t
+// as a local time followed by the time remaining until then.
+func formatReset(t time.Time) string {
+	d := time.Until(t).Round(time.Second)
+	if d < 0 {
+		d = 0
+	}
+	return fmt.Sprintf("%s (in %v)", t.Local().Format("15:04:05"), d)
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// searchesFile is the name of the file defining saved searches,
+// relative to $HOME.
+const searchesFile = ".github-issue-searches"
+
+var (
+	searchesOnce sync.Once
+	searches     map[string]string
+	searchesErr  error
+)
+
+// loadSearches reads the saved searches from $HOME/.github-issue-searches.
+// Each non-blank line not beginning with # defines a search,
+// in the form
+//
+//	name = query
+//
+// where either the name or the query may be written as a
+// double-quoted Go string. A missing file defines no searches.
+func loadSearches() (map[string]string, error) {
+	searchesOnce.Do(func() {
+		searches = make(map[string]string)
+		file := filepath.Join(os.Getenv("HOME"), searchesFile)
+		data, err := os.ReadFile(file)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				searchesErr = err
+			}
+			return
+		}
+		for i, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			name, query, ok := strings.Cut(line, "=")
+			name, err1 := unquoteSearch(name)
+			query, err2 := unquoteSearch(query)
+			if !ok || name == "" || strings.ContainsAny(name, " \t@") || err1 != nil || err2 != nil {
+				searchesErr = fmt.Errorf("%s:%d: invalid saved search; want name = query", file, i+1)
+				return
+			}
+			searches[name] = query
+		}
+	})
+	return searches, searchesErr
+}
+
+// unquoteSearch returns s with surrounding spaces removed
+// and, if it is double-quoted, unquoted.
+func unquoteSearch(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, `"`) {
+		return strconv.Unquote(s)
+	}
+	return s, nil
+}
+
+// expandSearches returns the query q with each word of the form @name
+// replaced by the saved search with that name.
+// It returns an error if there is no such saved search.
+func expandSearches(q string) (string, error) {
+	if !strings.Contains(q, "@") {
+		return q, nil
+	}
+	words := strings.Fields(q)
+	changed := false
+	for i, word := range words {
+		name, ok := strings.CutPrefix(word, "@")
+		if !ok {
+			continue
+		}
+		all, err := loadSearches()
+		if err != nil {
+			return "", err
+		}
+		query, ok := all[name]
+		if !ok {
+			return "", fmt.Errorf("unknown saved search %s (not defined in $HOME/%s)", word, searchesFile)
+		}
+		words[i] = query
+		changed = true
+	}
+	if !changed {
+		return q, nil
+	}
+	return strings.Join(words, " "), nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github_test
+
+import (
+	"strings"
+	"testing"
+
+	"rsc.io/github"
+	"rsc.io/github/githubtest"
+)
+
+func TestMilestoneIssuesNoMilestone(t *testing.T) {
+	// GitHub answers a query for a missing milestone with null, not an error.
+	c := githubtest.Client(t, "testdata/nomilestone.json")
+	list, err := c.MilestoneIssues("rsc", "quote", &github.Milestone{Number: 99}, "open")
+	if err == nil || !strings.Contains(err.Error(), "no milestone 99") {
+		t.Fatalf("MilestoneIssues = %v, %v, want no milestone error", list, err)
+	}
+}
+
+func TestIssuesByNumberPartial(t *testing.T) {
+	// Issue 2 does not exist. GitHub returns the other issues
+	// along with a NOT_FOUND error for issue 2.
+	c := githubtest.Client(t, "testdata/issuesbynumber.json")
+	list, err := c.IssuesByNumber("rsc", "quote", []int{1, 2, 3})
+	if err == nil || !strings.Contains(err.Error(), "rsc/quote#2: graphql error: Could not resolve") {
+		t.Errorf("IssuesByNumber: err = %v, want error for #2", err)
+	}
+	if err != nil && (strings.Contains(err.Error(), "#1") || strings.Contains(err.Error(), "#3")) {
+		t.Errorf("IssuesByNumber: err = %v, want error only for #2", err)
+	}
+	if len(list) != 3 {
+		t.Fatalf("IssuesByNumber returned %d issues, want 3", len(list))
+	}
+	if list[0] == nil || list[0].Title != "first" || list[1] != nil || list[2] == nil || list[2].Title != "third" {
+		t.Errorf("IssuesByNumber = [%v %v %v], want [first nil third]", list[0], list[1], list[2])
+	}
+}
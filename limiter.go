@@ -0,0 +1,131 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"sync"
+	"time"
+)
+
+// A Limiter paces the requests made by one or more Clients,
+// so that concurrent callers share GitHub's rate limits
+// instead of independently running into them.
+// A Limiter is safe for concurrent use by multiple goroutines,
+// and a single Limiter is typically shared by all the Clients
+// using the same credentials (see [Client.WithLimiter]).
+//
+// A Limiter is a token bucket limiting both the number of requests
+// per second and the number of GraphQL rate limit points spent per hour.
+// When any request sharing the Limiter is refused because of a rate limit,
+// all requests sharing the Limiter wait for the limit to reset,
+// not just the one that was refused.
+type Limiter struct {
+	mu       sync.Mutex
+	requests bucket
+	points   bucket
+	until    time.Time // all requests wait until this time
+}
+
+// A bucket is a token bucket.
+type bucket struct {
+	rate   float64 // tokens per second; 0 means unlimited
+	burst  float64 // maximum tokens
+	tokens float64 // current tokens; may be negative
+	last   time.Time
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *bucket) refill(now time.Time) {
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+	} else {
+		b.tokens = b.burst
+	}
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+}
+
+// take removes n tokens and returns how long to wait until the bucket
+// would have held them.
+func (b *bucket) take(now time.Time, n float64) time.Duration {
+	if b.rate == 0 {
+		return 0
+	}
+	b.refill(now)
+	b.tokens -= n
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// NewLimiter returns a Limiter allowing at most requestsPerSecond requests per second
+// (in bursts of up to one second's worth) and at most pointsPerHour GraphQL
+// rate limit points per hour (in bursts of up to one minute's worth).
+// A zero value for either means no limit of that kind.
+// GitHub's primary rate limit for personal access tokens is 5,000 points per hour.
+func NewLimiter(requestsPerSecond float64, pointsPerHour int) *Limiter {
+	l := new(Limiter)
+	if requestsPerSecond > 0 {
+		l.requests = bucket{rate: requestsPerSecond, burst: max(1, requestsPerSecond)}
+	}
+	if pointsPerHour > 0 {
+		l.points = bucket{rate: float64(pointsPerHour) / 3600, burst: max(1, float64(pointsPerHour)/60)}
+	}
+	return l
+}
+
+// WithLimiter returns a shallow copy of c that paces its requests using l.
+// Passing a nil l removes any limiter.
+func (c *Client) WithLimiter(l *Limiter) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.limiter = l
+	return c2
+}
+
+// waitLimiter waits until c's limiter, if any, allows another request.
+// It returns an error only if c's context is canceled while waiting.
+func (c *Client) waitLimiter() error {
+	l := c.limiter
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	wait := l.requests.take(now, 1)
+	// Points are charged after each request, once its cost is known,
+	// so the points bucket only needs to be out of debt.
+	wait = max(wait, l.points.take(now, 0))
+	wait = max(wait, l.until.Sub(now))
+	l.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	return c.sleep(wait)
+}
+
+// chargeLimiter charges cost GraphQL points to c's limiter, if any.
+func (c *Client) chargeLimiter(cost int) {
+	if l := c.limiter; l != nil && cost > 0 {
+		l.mu.Lock()
+		l.points.take(time.Now(), float64(cost))
+		l.mu.Unlock()
+	}
+}
+
+// pauseLimiter makes all requests sharing c's limiter, if any,
+// wait for d, because a request was refused by a rate limit.
+func (c *Client) pauseLimiter(d time.Duration) {
+	if l := c.limiter; l != nil {
+		l.mu.Lock()
+		if until := time.Now().Add(d); until.After(l.until) {
+			l.until = until
+		}
+		l.mu.Unlock()
+	}
+}
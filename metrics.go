@@ -0,0 +1,50 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import "time"
+
+// A Metrics receives measurements of the requests made by a Client,
+// for export to a monitoring system such as Prometheus or expvar.
+// For example, using expvar:
+//
+//	type expvarMetrics struct {
+//		requests, errors, remaining expvar.Int
+//		latency                     expvar.Float // total seconds
+//	}
+//
+//	func (m *expvarMetrics) ObserveRequest(api string, d time.Duration, err error) {
+//		m.requests.Add(1)
+//		if err != nil {
+//			m.errors.Add(1)
+//		}
+//		m.latency.Add(d.Seconds())
+//	}
+//
+//	func (m *expvarMetrics) SetRateLimitRemaining(n int) {
+//		m.remaining.Set(int64(n))
+//	}
+//
+// A Client calls its Metrics from whichever goroutine is making the request,
+// so implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest is called after each HTTP request, including each retry,
+	// with the API used ("graphql" or "rest"), the time taken,
+	// and the error from the request, or nil if it succeeded.
+	ObserveRequest(api string, d time.Duration, err error)
+
+	// SetRateLimitRemaining is called with the number of GraphQL
+	// rate limit points remaining after each GraphQL request that reports it.
+	SetRateLimitRemaining(n int)
+}
+
+// WithMetrics returns a shallow copy of c that reports measurements to m.
+// Passing a nil m turns off reporting.
+func (c *Client) WithMetrics(m Metrics) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.metrics = m
+	return c2
+}
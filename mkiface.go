@@ -0,0 +1,159 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+
+// go run mkiface.go generates iface.go, which defines ClientInterface,
+// from the exported methods of *Client in this directory.
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("mkiface: ")
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != "iface.go"
+	}, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	type method struct {
+		name string
+		doc  string
+		sig  string
+	}
+	var methods []method
+	imports := make(map[string]string) // path -> file import spec
+	for _, file := range pkgs["github"].Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !fn.Name.IsExported() || !isClient(fn.Recv.List[0].Type) {
+				continue
+			}
+			// Skip methods returning *Client, such as WithContext:
+			// they configure a Client and make no sense in an interface.
+			if returnsClient(fn.Type) {
+				continue
+			}
+			var buf bytes.Buffer
+			printer.Fprint(&buf, fset, fn.Type)
+			sig := strings.TrimPrefix(buf.String(), "func")
+			methods = append(methods, method{fn.Name.Name, firstSentence(fn.Doc.Text()), sig})
+			for _, imp := range file.Imports {
+				path := strings.Trim(imp.Path.Value, `"`)
+				name := path[strings.LastIndex(path, "/")+1:]
+				if imp.Name != nil {
+					name = imp.Name.Name
+				}
+				if strings.Contains(sig, name+".") {
+					imports[path] = imp.Path.Value
+				}
+			}
+		}
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i].name < methods[j].name })
+
+	var buf bytes.Buffer
+	buf.WriteString(`// Code generated by mkiface.go; DO NOT EDIT.
+
+package github
+
+`)
+	if len(imports) > 0 {
+		// Standard library imports first, then others.
+		var std, other []string
+		for path, spec := range imports {
+			if strings.Contains(strings.Split(path, "/")[0], ".") {
+				other = append(other, spec)
+			} else {
+				std = append(std, spec)
+			}
+		}
+		sort.Strings(std)
+		sort.Strings(other)
+		buf.WriteString("import (\n")
+		for _, p := range std {
+			buf.WriteString("\t" + p + "\n")
+		}
+		if len(std) > 0 && len(other) > 0 {
+			buf.WriteString("\n")
+		}
+		for _, p := range other {
+			buf.WriteString("\t" + p + "\n")
+		}
+		buf.WriteString(")\n\n")
+	}
+	buf.WriteString(`// A ClientInterface is implemented by [*Client].
+// It lists the Client's methods for accessing GitHub,
+// so that code using a Client can be tested with a fake implementation.
+// It omits the methods that configure a Client, such as [Client.WithContext].
+//
+// New methods may be added to ClientInterface as they are added to Client.
+// Fakes should embed ClientInterface, leaving it nil,
+// and define only the methods they need.
+type ClientInterface interface {
+`)
+	for _, m := range methods {
+		if m.doc != "" {
+			buf.WriteString("\t// " + m.doc + "\n")
+		}
+		buf.WriteString("\t" + m.name + m.sig + "\n")
+	}
+	buf.WriteString("}\n\nvar _ ClientInterface = (*Client)(nil)\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting: %v\n%s", err, buf.Bytes())
+	}
+	if err := os.WriteFile("iface.go", src, 0666); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// isClient reports whether t is *Client.
+func isClient(t ast.Expr) bool {
+	star, ok := t.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	id, ok := star.X.(*ast.Ident)
+	return ok && id.Name == "Client"
+}
+
+// returnsClient reports whether the function type t returns a *Client.
+func returnsClient(t *ast.FuncType) bool {
+	if t.Results == nil {
+		return false
+	}
+	for _, r := range t.Results.List {
+		if isClient(r.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstSentence returns the first sentence of the doc comment text, on one line.
+func firstSentence(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if i := strings.Index(text, ". "); i >= 0 {
+		text = text[:i+1]
+	}
+	return text
+}
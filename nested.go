@@ -0,0 +1,52 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import "rsc.io/github/schema"
+
+// A query can only fetch one page of a connection nested inside
+// a list of other nodes, such as the labels of each issue in a milestone.
+// When a nested connection has more pages, the conversion code
+// (such as toIssue) records the connection's end cursor,
+// and the method returning the list calls collectMore
+// to fetch the remaining pages for each node.
+
+// collectMore fetches the remaining pages of a connection nested inside
+// the node with the given ID, whose GraphQL type is typ, starting after cursor.
+// The connection is the selection for the connection field,
+// using the $Cursor and $PageSize variables, as in:
+//
+//	labels(first: $PageSize, after: $Cursor) {
+//	  pageInfo { hasNextPage endCursor }
+//	  nodes { name }
+//	}
+//
+// The page function returns the connection from the node,
+// which collectMore passes as type Node, such as *schema.Issue.
+func collectMore[Node, Schema, Out any](c *Client, id, typ, cursor, connection string, transform func(Schema) Out,
+	page func(Node) pager[Schema]) ([]Out, error) {
+	graphql := `
+	  query($ID: ID!, $Cursor: String, $PageSize: Int = 100) {
+	    node(id: $ID) {
+	      __typename
+	      ... on ` + typ + ` {
+	        ` + connection + `
+	      }
+	    }
+	  }
+	`
+
+	// The limit on the outer list does not apply to the nested connection:
+	// a node's remaining labels are all needed, however many there are.
+	vars := Vars{"ID": id, "Cursor": cursor}
+	return collect(c.WithLimit(0), graphql, vars, transform,
+		func(q *schema.Query) pager[Schema] {
+			if n, ok := q.Node.Interface.(Node); ok {
+				return page(n)
+			}
+			return nil
+		},
+	)
+}
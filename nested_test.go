@@ -0,0 +1,29 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github_test
+
+import (
+	"testing"
+
+	"rsc.io/github/githubtest"
+)
+
+func TestMoreLabelsIgnoresLimit(t *testing.T) {
+	// The issue has three labels but the first query returns only one.
+	// The limit applies to lists of issues, not to the labels of an issue,
+	// so the remaining two must be fetched even with WithLimit(1).
+	c := githubtest.Client(t, "testdata/morelabels.json").WithLimit(1)
+	issue, err := c.Issue("rsc", "quote", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, lab := range issue.Labels {
+		names = append(names, lab.Name)
+	}
+	if len(names) != 3 || names[0] != "a" || names[1] != "b" || names[2] != "c" {
+		t.Errorf("labels = %v, want [a b c]", names)
+	}
+}
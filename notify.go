@@ -0,0 +1,125 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Notification is a notification thread in the authenticated user's inbox.
+type Notification struct {
+	ID         string
+	Owner      string
+	Repo       string
+	Unread     bool
+	Reason     string // assign, author, comment, mention, review_requested, subscribed, ...
+	UpdatedAt  time.Time
+	LastReadAt time.Time // zero if never read
+	Type       string    // Issue, PullRequest, Commit, Release, Discussion, ...
+	Title      string
+	Number     int    // issue or pull request number, if any
+	URL        string // API URL of the subject
+}
+
+type notificationJSON struct {
+	ID         string    `json:"id"`
+	Unread     bool      `json:"unread"`
+	Reason     string    `json:"reason"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	LastReadAt time.Time `json:"last_read_at"`
+	Subject    struct {
+		Title string `json:"title"`
+		URL   string `json:"url"`
+		Type  string `json:"type"`
+	} `json:"subject"`
+	Repository struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+}
+
+func toNotification(j *notificationJSON) *Notification {
+	n := &Notification{
+		ID:         j.ID,
+		Owner:      j.Repository.Owner.Login,
+		Repo:       j.Repository.Name,
+		Unread:     j.Unread,
+		Reason:     j.Reason,
+		UpdatedAt:  j.UpdatedAt,
+		LastReadAt: j.LastReadAt,
+		Type:       j.Subject.Type,
+		Title:      j.Subject.Title,
+		URL:        j.Subject.URL,
+	}
+	if j.Subject.Type == "Issue" || j.Subject.Type == "PullRequest" {
+		if i := strings.LastIndex(j.Subject.URL, "/"); i >= 0 {
+			n.Number, _ = strconv.Atoi(j.Subject.URL[i+1:])
+		}
+	}
+	return n
+}
+
+// NotificationOptions restricts the notifications returned by Notifications.
+type NotificationOptions struct {
+	All           bool      // include notifications already marked read
+	Participating bool      // only notifications where the user is directly involved
+	Since         time.Time // only notifications updated at or after Since
+	Before        time.Time // only notifications updated before Before
+	Owner, Repo   string    // only notifications for the repository Owner/Repo
+}
+
+// Notifications returns the authenticated user's notifications,
+// most recently updated first.
+// If opts is nil, Notifications returns all unread notifications.
+func (c *Client) Notifications(opts *NotificationOptions) ([]*Notification, error) {
+	path := "/notifications"
+	q := url.Values{}
+	if opts != nil {
+		if opts.Owner != "" && opts.Repo != "" {
+			path = "/repos/" + opts.Owner + "/" + opts.Repo + "/notifications"
+		}
+		if opts.All {
+			q.Set("all", "true")
+		}
+		if opts.Participating {
+			q.Set("participating", "true")
+		}
+		if !opts.Since.IsZero() {
+			q.Set("since", opts.Since.UTC().Format(time.RFC3339))
+		}
+		if !opts.Before.IsZero() {
+			q.Set("before", opts.Before.UTC().Format(time.RFC3339))
+		}
+	}
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	list, err := restCollect[*notificationJSON](c, path, "")
+	return apply(toNotification, list), err
+}
+
+// MarkNotificationRead marks the notification thread as read.
+func (c *Client) MarkNotificationRead(n *Notification) error {
+	if err := c.rest("PATCH", "/notifications/threads/"+n.ID, nil, nil); err != nil {
+		return err
+	}
+	n.Unread = false
+	return nil
+}
+
+// MarkThreadDone marks the notification thread as done,
+// removing it from the inbox.
+func (c *Client) MarkThreadDone(n *Notification) error {
+	if err := c.rest("DELETE", "/notifications/threads/"+n.ID, nil, nil); err != nil {
+		return err
+	}
+	n.Unread = false
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"fmt"
+	"time"
+
+	"rsc.io/github/schema"
+)
+
+// An OrgMember is a member of an organization.
+type OrgMember struct {
+	Login     string
+	Name      string
+	Role      schema.OrganizationMemberRole // ADMIN or MEMBER
+	TwoFactor *bool                         // two-factor authentication enabled; nil if not visible to authenticated user
+}
+
+// orgMemberEdge is schema.OrganizationMemberEdge
+// but distinguishing an unknown two-factor status from a disabled one.
+type orgMemberEdge struct {
+	Role                schema.OrganizationMemberRole
+	HasTwoFactorEnabled *bool
+	Node                *schema.User
+}
+
+// OrgMembers returns the members of the organization.
+func (c *Client) OrgMembers(org string) ([]*OrgMember, error) {
+	graphql := `
+	  query($Org: String!, $Cursor: String, $PageSize: Int = 100) {
+	    organization(login: $Org) {
+	      membersWithRole(first: $PageSize, after: $Cursor) {
+	        pageInfo {
+	          hasNextPage
+	          endCursor
+	        }
+	        totalCount
+	        edges {
+	          role
+	          hasTwoFactorEnabled
+	          node { login name }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	type reply struct {
+		Organization *struct {
+			MembersWithRole *edges[*orgMemberEdge]
+		}
+	}
+	toOrgMember := func(e *orgMemberEdge) *OrgMember {
+		m := &OrgMember{Role: e.Role, TwoFactor: e.HasTwoFactorEnabled}
+		if e.Node != nil {
+			m.Login = e.Node.Login
+			m.Name = e.Node.Name
+		}
+		return m
+	}
+	var noOrg error
+	list, err := collectReply(c, graphql, Vars{"Org": org}, toOrgMember,
+		func(r *reply) pager[*orgMemberEdge] {
+			if r.Organization == nil || r.Organization.MembersWithRole == nil {
+				noOrg = fmt.Errorf("no organization %s", org)
+				return nil
+			}
+			return r.Organization.MembersWithRole
+		},
+	)
+	if err == nil {
+		err = noOrg
+	}
+	return list, err
+}
+
+// An OrgInvitation is a pending invitation to join an organization.
+type OrgInvitation struct {
+	ID        int64
+	Login     string // empty if invited by email
+	Email     string // empty if invited by login
+	Role      string // direct_member, admin, billing_manager, ...
+	Inviter   string
+	Teams     int // number of teams the invitee will join
+	CreatedAt time.Time
+}
+
+type orgInvitationJSON struct {
+	ID        int64     `json:"id"`
+	Login     string    `json:"login"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	TeamCount int       `json:"team_count"`
+	CreatedAt time.Time `json:"created_at"`
+	Inviter   struct {
+		Login string `json:"login"`
+	} `json:"inviter"`
+}
+
+// OrgInvitations returns the pending invitations to join the organization.
+// Listing invitations requires organization owner permissions.
+func (c *Client) OrgInvitations(org string) ([]*OrgInvitation, error) {
+	list, err := restCollect[*orgInvitationJSON](c, "/orgs/"+org+"/invitations", "")
+	var invs []*OrgInvitation
+	for _, j := range list {
+		invs = append(invs, &OrgInvitation{
+			ID:        j.ID,
+			Login:     j.Login,
+			Email:     j.Email,
+			Role:      j.Role,
+			Inviter:   j.Inviter.Login,
+			Teams:     j.TeamCount,
+			CreatedAt: j.CreatedAt,
+		})
+	}
+	return invs, err
+}
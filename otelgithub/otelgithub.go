@@ -0,0 +1,164 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package otelgithub adds OpenTelemetry tracing to a [github.Client].
+//
+// Typical usage is:
+//
+//	c, err := github.Dial("")
+//	...
+//	c = otelgithub.Instrument(c, nil)
+//
+// Each HTTP request made by the instrumented client, including each retry,
+// is recorded as a span named for the GraphQL operation
+// (such as "query repository" or "mutation addComment")
+// or, for REST requests, the HTTP method and URL path.
+// Spans are children of the span in the client's context (see [github.Client.WithContext]).
+package otelgithub
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"rsc.io/github"
+)
+
+const tracerName = "rsc.io/github/otelgithub"
+
+// Instrument returns a copy of c that records a span for each request using tp.
+// If tp is nil, Instrument uses the global TracerProvider (see [otel.GetTracerProvider]),
+// which records nothing unless the program has configured one.
+//
+// Instrument sets the copy's OnRequest and OnResponse hooks,
+// replacing any set earlier with [github.Client.WithOnRequest] or [github.Client.WithOnResponse].
+func Instrument(c *github.Client, tp trace.TracerProvider) *github.Client {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	tracer := tp.Tracer(tracerName)
+	var spans sync.Map // *github.RequestInfo -> trace.Span
+
+	onRequest := func(r *github.RequestInfo) {
+		name, attrs := describe(r)
+		_, span := tracer.Start(r.Context, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...))
+		spans.Store(r, span)
+	}
+	onResponse := func(r *github.ResponseInfo) {
+		v, ok := spans.LoadAndDelete(r.Request)
+		if !ok {
+			return
+		}
+		span := v.(trace.Span)
+		if r.StatusCode != 0 {
+			span.SetAttributes(attribute.Int("http.response.status_code", r.StatusCode))
+		}
+		if r.Request.Query != "" {
+			span.SetAttributes(attribute.Int("github.graphql.cost", r.Cost))
+		}
+		if r.Err != nil {
+			span.RecordError(r.Err)
+			span.SetStatus(codes.Error, r.Err.Error())
+		}
+		span.End()
+	}
+	return c.WithOnRequest(onRequest).WithOnResponse(onResponse)
+}
+
+// describe returns the span name and attributes for the request r.
+func describe(r *github.RequestInfo) (string, []attribute.KeyValue) {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", r.Method),
+		attribute.String("url.full", r.URL),
+		attribute.Int("github.attempt", r.Attempt),
+	}
+	if r.Query == "" {
+		path := r.URL
+		if u, err := url.Parse(r.URL); err == nil {
+			path = u.Path
+		}
+		return r.Method + " " + path, attrs
+	}
+	typ, name := operation(r.Query)
+	attrs = append(attrs,
+		attribute.String("graphql.operation.type", typ),
+		attribute.String("graphql.operation.name", name),
+	)
+	return typ + " " + name, attrs
+}
+
+// operation returns the type ("query" or "mutation") and name of the GraphQL operation in query.
+// If the operation is unnamed, as most in package github are,
+// operation uses the name of its first top-level field instead.
+func operation(query string) (typ, name string) {
+	q := strings.TrimSpace(query)
+	typ = "query"
+	for _, t := range []string{"query", "mutation", "subscription"} {
+		if rest, ok := strings.CutPrefix(q, t); ok && (rest == "" || !isIdent(rest[0])) {
+			typ = t
+			q = strings.TrimSpace(rest)
+			if name := ident(q); name != "" {
+				return typ, name
+			}
+			break
+		}
+	}
+	// Skip variable declarations and find the first field.
+	depth := 0
+	for i := 0; i < len(q); i++ {
+		switch q[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '{':
+			if depth == 0 {
+				return typ, firstField(q[i+1:])
+			}
+		}
+	}
+	return typ, ""
+}
+
+// firstField returns the name of the first field in the selection set s,
+// skipping the rateLimit field that the Client adds to queries.
+func firstField(s string) string {
+	s = strings.TrimSpace(s)
+	name := ident(s)
+	if name != "rateLimit" {
+		return name
+	}
+	i := strings.Index(s, "}")
+	if i < 0 {
+		return name
+	}
+	if next := ident(strings.TrimSpace(s[i+1:])); next != "" {
+		return next
+	}
+	return name
+}
+
+// ident returns the identifier at the start of s, if any.
+// It skips a leading alias ("alias: field" yields "field").
+func ident(s string) string {
+	i := 0
+	for i < len(s) && isIdent(s[i]) {
+		i++
+	}
+	id := s[:i]
+	if rest := strings.TrimSpace(s[i:]); strings.HasPrefix(rest, ":") && id != "" {
+		return ident(strings.TrimSpace(rest[1:]))
+	}
+	return id
+}
+
+func isIdent(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_'
+}
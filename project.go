@@ -11,74 +11,86 @@ import (
 	"rsc.io/github/schema"
 )
 
+const projectCommonField = `
+  createdAt
+  dataType
+  id
+  name
+  updatedAt
+`
+
+const projectFieldFields = `
+  __typename
+  ... on ProjectV2Field {
+    ` + projectCommonField + `
+  }
+  ... on ProjectV2IterationField {
+    ` + projectCommonField + `
+    configuration {
+      completedIterations {
+        duration
+        id
+        startDate
+        title
+        titleHTML
+      }
+      iterations {
+        duration
+        id
+        startDate
+        title
+        titleHTML
+      }
+      duration
+      startDay
+    }
+  }
+  ... on ProjectV2SingleSelectField {
+    ` + projectCommonField + `
+    options {
+      id
+      name
+      nameHTML
+    }
+  }
+`
+
+const projectFields = `
+  closed
+  closedAt
+  createdAt
+  updatedAt
+  id
+  number
+  title
+  shortDescription
+  readme
+  public
+  url
+  fields(first: 100) {
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+    totalCount
+    nodes {
+      ` + projectFieldFields + `
+    }
+  }
+`
+
 func (c *Client) Projects(org, query string) ([]*Project, error) {
-	commonField := `
-	  createdAt
-	  dataType
-	  id
-	  name
-	  updatedAt
-	`
 	graphql := `
-	  query($Org: String!, $Query: String, $Cursor: String) {
+	  query($Org: String!, $Query: String, $Cursor: String, $PageSize: Int = 100) {
 	    organization(login: $Org) {
-	      projectsV2(first: 100, query: $Query, after: $Cursor) {
+	      projectsV2(first: $PageSize, query: $Query, after: $Cursor) {
 	        pageInfo {
 	          hasNextPage
 	          endCursor
 	        }
 	        totalCount
 	        nodes {
-	          closed
-	          closedAt
-	          createdAt
-	          updatedAt
-	          id
-	          number
-	          title
-	          url
-	          fields(first: 100) {
-	            pageInfo {
-	              hasNextPage
-	              endCursor
-	            }
-	            totalCount
-	            nodes {
-	              __typename
-	              ... on ProjectV2Field {
-	                ` + commonField + `
-	              }
-	              ... on ProjectV2IterationField {
-	                ` + commonField + `
-	                configuration {
-	                  completedIterations {
-	                    duration
-	                    id
-	                    startDate
-	                    title
-	                    titleHTML
-	                  }
-	                  iterations {
-	                    duration
-	                    id
-	                    startDate
-	                    title
-	                    titleHTML
-	                  }
-	                  duration
-	                  startDay
-	                }
-	              }
-	              ... on ProjectV2SingleSelectField {
-	                ` + commonField + `
-	                options {
-	                  id
-	                  name
-	                  nameHTML
-	                }
-	              }
-	            }
-	          }
+	          ` + projectFields + `
 	        }
 	      }
 	    }
@@ -89,88 +101,297 @@ func (c *Client) Projects(org, query string) ([]*Project, error) {
 	if query != "" {
 		vars["Query"] = query
 	}
-	return collect(c, graphql, vars,
+	list, err := collect(c, graphql, vars,
 		toProject(org),
 		func(q *schema.Query) pager[*schema.ProjectV2] { return q.Organization.ProjectsV2 },
 	)
+	if err != nil {
+		return list, err
+	}
+	return list, c.moreProjectFields(list)
 }
 
-func (c *Client) ProjectItems(p *Project) ([]*ProjectItem, error) {
+func (c *Client) UserProjects(user, query string) ([]*Project, error) {
+	graphql := `
+	  query($User: String!, $Query: String, $Cursor: String, $PageSize: Int = 100) {
+	    user(login: $User) {
+	      projectsV2(first: $PageSize, query: $Query, after: $Cursor) {
+	        pageInfo {
+	          hasNextPage
+	          endCursor
+	        }
+	        totalCount
+	        nodes {
+	          ` + projectFields + `
+	        }
+	      }
+	    }
+	  }
+	`
+
+	vars := Vars{"User": user}
+	if query != "" {
+		vars["Query"] = query
+	}
+	list, err := collect(c, graphql, vars,
+		toProject(user),
+		func(q *schema.Query) pager[*schema.ProjectV2] { return q.User.ProjectsV2 },
+	)
+	if err != nil {
+		return list, err
+	}
+	return list, c.moreProjectFields(list)
+}
+
+// RepoProjects returns the projects linked to the repository org/repo.
+func (c *Client) RepoProjects(org, repo, query string) ([]*Project, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Query: String, $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      projectsV2(first: $PageSize, query: $Query, after: $Cursor) {
+	        pageInfo {
+	          hasNextPage
+	          endCursor
+	        }
+	        totalCount
+	        nodes {
+	          ` + projectFields + `
+	        }
+	      }
+	    }
+	  }
+	`
+
+	vars := Vars{"Org": org, "Repo": repo}
+	if query != "" {
+		vars["Query"] = query
+	}
+	list, err := collect(c, graphql, vars,
+		toProject(org),
+		func(q *schema.Query) pager[*schema.ProjectV2] { return q.Repository.ProjectsV2 },
+	)
+	if err != nil {
+		return list, err
+	}
+	return list, c.moreProjectFields(list)
+}
+
+func (c *Client) CreateProject(org, title string) (*Project, error) {
 	graphql := `
-	  query($Org: String!, $ProjectNumber: Int!, $Cursor: String) {
+	  query($Org: String!) {
 	    organization(login: $Org) {
-	      projectV2(number: $ProjectNumber) {
-	        items(first: 100, after: $Cursor) {
+	      id
+	    }
+	  }
+	`
+	q, err := c.GraphQLQuery(graphql, Vars{"Org": org})
+	if err != nil {
+		return nil, err
+	}
+
+	graphql = `
+	  mutation($Owner: ID!, $Title: String!) {
+	    createProjectV2(input: {ownerId: $Owner, title: $Title}) {
+	      clientMutationId
+	      projectV2 {
+	        ` + projectFields + `
+	      }
+	    }
+	  }
+	`
+	m, err := c.GraphQLMutation(graphql, Vars{"Owner": q.Organization.Id, "Title": title})
+	if err != nil {
+		return nil, err
+	}
+	if m.CreateProjectV2 == nil || m.CreateProjectV2.ProjectV2 == nil {
+		return nil, c.noResult("createProjectV2")
+	}
+	return toProject(org)(m.CreateProjectV2.ProjectV2), nil
+}
+
+// ProjectSettings holds the settings of a project that can be changed by [Client.UpdateProject].
+type ProjectSettings struct {
+	Title            string
+	ShortDescription string
+	Readme           string
+	Public           bool
+	Closed           bool
+}
+
+// Settings returns the current settings of the project,
+// suitable for modifying and passing to [Client.UpdateProject].
+func (p *Project) Settings() *ProjectSettings {
+	return &ProjectSettings{
+		Title:            p.Title,
+		ShortDescription: p.ShortDescription,
+		Readme:           p.Readme,
+		Public:           p.Public,
+		Closed:           p.Closed,
+	}
+}
+
+func (c *Client) UpdateProject(p *Project, settings *ProjectSettings) (*Project, error) {
+	graphql := `
+	  mutation($Project: ID!, $Title: String!, $ShortDescription: String!, $Readme: String!, $Public: Boolean!, $Closed: Boolean!) {
+	    updateProjectV2(input: {projectId: $Project, title: $Title, shortDescription: $ShortDescription, readme: $Readme, public: $Public, closed: $Closed}) {
+	      clientMutationId
+	      projectV2 {
+	        ` + projectFields + `
+	      }
+	    }
+	  }
+	`
+	vars := Vars{
+		"Project":          p.ID,
+		"Title":            settings.Title,
+		"ShortDescription": settings.ShortDescription,
+		"Readme":           settings.Readme,
+		"Public":           settings.Public,
+		"Closed":           settings.Closed,
+	}
+	m, err := c.GraphQLMutation(graphql, vars)
+	if err != nil {
+		return nil, err
+	}
+	if m.UpdateProjectV2 == nil || m.UpdateProjectV2.ProjectV2 == nil {
+		return nil, c.noResult("updateProjectV2")
+	}
+	return toProject(p.Org)(m.UpdateProjectV2.ProjectV2), nil
+}
+
+// CreateProjectField adds a new field with the given name and data type to the project.
+// The data type must be one of TEXT, NUMBER, DATE, or SINGLE_SELECT.
+// For SINGLE_SELECT fields, options lists the names of the field's options.
+func (c *Client) CreateProjectField(p *Project, name string, dataType schema.ProjectV2FieldType, options ...string) (*ProjectField, error) {
+	graphql := `
+	  mutation($Project: ID!, $Name: String!, $DataType: ProjectV2CustomFieldType!, $Options: [ProjectV2SingleSelectFieldOptionInput!]) {
+	    createProjectV2Field(input: {projectId: $Project, name: $Name, dataType: $DataType, singleSelectOptions: $Options}) {
+	      clientMutationId
+	      projectV2Field {
+	        ` + projectFieldFields + `
+	      }
+	    }
+	  }
+	`
+	vars := Vars{"Project": p.ID, "Name": name, "DataType": dataType}
+	if len(options) > 0 {
+		var list []map[string]string
+		for _, o := range options {
+			list = append(list, map[string]string{"name": o, "color": "GRAY", "description": ""})
+		}
+		vars["Options"] = list
+	}
+
+	// The schema snapshot predates createProjectV2Field,
+	// so decode the reply directly instead of using schema.Mutation.
+	var reply struct {
+		CreateProjectV2Field struct {
+			ProjectV2Field schema.ProjectV2FieldConfiguration
+		}
+	}
+	if err := c.graphQL(graphql, vars, &reply); err != nil {
+		return nil, err
+	}
+	if reply.CreateProjectV2Field.ProjectV2Field.Interface == nil {
+		return nil, c.noResult("createProjectV2Field")
+	}
+	f := toProjectField(reply.CreateProjectV2Field.ProjectV2Field)
+	p.Fields = append(p.Fields, f)
+	return f, nil
+}
+
+const projectFieldValueFields = `
+  __typename
+  ... on ProjectV2ItemFieldDateValue {
+    createdAt databaseId id updatedAt
+    date
+    field { __typename ... on ProjectV2Field { databaseId id name } }
+  }
+  ... on ProjectV2ItemFieldIterationValue {
+    createdAt databaseId id updatedAt
+    iterationId title titleHTML startDate duration
+    field { __typename ... on ProjectV2IterationField { databaseId id name } }
+  }
+  ... on ProjectV2ItemFieldLabelValue {
+    field { __typename ... on ProjectV2Field { databaseId id name } }
+  }
+  ... on ProjectV2ItemFieldMilestoneValue {
+    field { __typename ... on ProjectV2Field { databaseId id name } }
+  }
+  ... on ProjectV2ItemFieldNumberValue {
+    createdAt databaseId id updatedAt
+    number
+    field { __typename ... on ProjectV2Field { databaseId id name } }
+  }
+  ... on ProjectV2ItemFieldPullRequestValue {
+    field { __typename ... on ProjectV2Field { databaseId id name } }
+  }
+  ... on ProjectV2ItemFieldRepositoryValue {
+    field { __typename ... on ProjectV2Field { databaseId id name } }
+  }
+  ... on ProjectV2ItemFieldReviewerValue {
+    field { __typename ... on ProjectV2Field { databaseId id name } }
+  }
+  ... on ProjectV2ItemFieldSingleSelectValue {
+    createdAt databaseId id updatedAt
+    name nameHTML optionId
+    field { __typename ... on ProjectV2SingleSelectField { databaseId id name } }
+  }
+  ... on ProjectV2ItemFieldTextValue {
+    createdAt databaseId id updatedAt
+    text
+    field { __typename ... on ProjectV2Field { databaseId id name } }
+  }
+  ... on ProjectV2ItemFieldUserValue {
+    field { __typename ... on ProjectV2Field { databaseId id name } }
+  }
+`
+
+const projectItemFields = `
+  databaseId
+  fieldValues(first: 100) {
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+    totalCount
+    nodes {
+      ` + projectFieldValueFields + `
+    }
+  }
+  id
+  isArchived
+  type
+  updatedAt
+  createdAt
+  content {
+    __typename
+    ... on Issue {
+      ` + issueFields + `
+    }
+    ... on PullRequest {
+      ` + pullRequestFields + `
+    }
+    ... on DraftIssue {
+      ` + draftIssueFields + `
+    }
+  }
+`
+
+func (c *Client) ProjectItems(p *Project) ([]*ProjectItem, error) {
+	graphql := `
+	  query($Project: ID!, $Cursor: String, $PageSize: Int = 100) {
+	    node(id: $Project) {
+	      __typename
+	      ... on ProjectV2 {
+	        items(first: $PageSize, after: $Cursor) {
 	          pageInfo {
 	            hasNextPage
 	            endCursor
 	          }
 	          totalCount
 	          nodes {
-	            databaseId
-	            fieldValues(first: 100) {
-	              pageInfo {
-	                hasNextPage
-	                endCursor
-	              }
-	              totalCount
-	              nodes {
-	                __typename
-	                ... on ProjectV2ItemFieldDateValue {
-	                  createdAt databaseId id updatedAt
-	                  date
-	                  field { __typename ... on ProjectV2Field { databaseId id name } }
-	                }
-	                ... on ProjectV2ItemFieldIterationValue {
-	                  createdAt databaseId id updatedAt
-	                  field { __typename ... on ProjectV2IterationField { databaseId id name } }
-	                }
-	                ... on ProjectV2ItemFieldLabelValue {
-	                  field { __typename ... on ProjectV2Field { databaseId id name } }
-	                }
-	                ... on ProjectV2ItemFieldMilestoneValue {
-	                  field { __typename ... on ProjectV2Field { databaseId id name } }
-	                }
-	                ... on ProjectV2ItemFieldNumberValue {
-	                  createdAt databaseId id updatedAt
-	                  number
-	                  field { __typename ... on ProjectV2Field { databaseId id name } }
-	                }
-	                ... on ProjectV2ItemFieldPullRequestValue {
-	                  field { __typename ... on ProjectV2Field { databaseId id name } }
-	                }
-	                ... on ProjectV2ItemFieldRepositoryValue {
-	                  field { __typename ... on ProjectV2Field { databaseId id name } }
-	                }
-	                ... on ProjectV2ItemFieldReviewerValue {
-	                  field { __typename ... on ProjectV2Field { databaseId id name } }
-	                }
-	                ... on ProjectV2ItemFieldSingleSelectValue {
-	                  createdAt databaseId id updatedAt
-	                  name nameHTML optionId
-	                  field { __typename ... on ProjectV2SingleSelectField { databaseId id name } }
-	                }
-	                ... on ProjectV2ItemFieldTextValue {
-	                  createdAt databaseId id updatedAt
-	                  text
-	                  field { __typename ... on ProjectV2Field { databaseId id name } }
-	                }
-	                ... on ProjectV2ItemFieldUserValue {
-	                  field { __typename ... on ProjectV2Field { databaseId id name } }
-	                }
-	              }
-	            }
-	            id
-	            isArchived
-	            type
-	            updatedAt
-	            createdAt
-	            content {
-	              __typename
-	              ... on Issue {
-	                ` + issueFields + `
-	              }
-	            }
+	            ` + projectItemFields + `
 	          }
 	        }
 	      }
@@ -178,11 +399,41 @@ func (c *Client) ProjectItems(p *Project) ([]*ProjectItem, error) {
 	  }
 	`
 
-	vars := Vars{"Org": p.Org, "ProjectNumber": p.Number}
-	return collect(c, graphql, vars,
+	vars := Vars{"Project": p.ID}
+	list, err := collect(c, graphql, vars,
 		p.toProjectItem,
-		func(q *schema.Query) pager[*schema.ProjectV2Item] { return q.Organization.ProjectV2.Items },
+		func(q *schema.Query) pager[*schema.ProjectV2Item] {
+			if sp, ok := q.Node.Interface.(*schema.ProjectV2); ok {
+				return sp.Items
+			}
+			return nil
+		},
 	)
+	if err != nil {
+		return list, err
+	}
+	return list, c.moreProjectItemFields(p, list)
+}
+
+func (c *Client) AddProjectDraftIssue(p *Project, title, body string) (*ProjectItem, error) {
+	graphql := `
+	  mutation($Project: ID!, $Title: String!, $Body: String!) {
+	    addProjectV2DraftIssue(input: {projectId: $Project, title: $Title, body: $Body}) {
+	      clientMutationId
+	      projectItem {
+	        ` + projectItemFields + `
+	      }
+	    }
+	  }
+	`
+	m, err := c.GraphQLMutation(graphql, Vars{"Project": p.ID, "Title": title, "Body": body})
+	if err != nil {
+		return nil, err
+	}
+	if m.AddProjectV2DraftIssue == nil || m.AddProjectV2DraftIssue.ProjectItem == nil {
+		return nil, c.noResult("addProjectV2DraftIssue")
+	}
+	return p.toProjectItem(m.AddProjectV2DraftIssue.ProjectItem), nil
 }
 
 type Project struct {
@@ -195,7 +446,13 @@ type Project struct {
 	Number    int
 	Title     string
 	URL       string
-	Org       string
+	Org       string // login of project owner (organization or user)
+
+	ShortDescription string
+	Readme           string
+	Public           bool
+
+	moreFields string // cursor for fetching remaining fields
 }
 
 func (p *Project) FieldByName(name string) *ProjectField {
@@ -209,8 +466,7 @@ func (p *Project) FieldByName(name string) *ProjectField {
 
 func toProject(org string) func(*schema.ProjectV2) *Project {
 	return func(s *schema.ProjectV2) *Project {
-		// TODO: Check p.Fields.PageInfo.HasNextPage.
-		return &Project{
+		p := &Project{
 			ID:        string(s.Id),
 			Closed:    s.Closed,
 			ClosedAt:  toTime(s.ClosedAt),
@@ -221,15 +477,60 @@ func toProject(org string) func(*schema.ProjectV2) *Project {
 			Title:     s.Title,
 			URL:       string(s.Url),
 			Org:       org,
+
+			ShortDescription: s.ShortDescription,
+			Readme:           s.Readme,
+			Public:           s.Public,
 		}
+		if s.Fields.PageInfo.HasNextPage {
+			p.moreFields = s.Fields.PageInfo.EndCursor
+		}
+		return p
 	}
 }
 
+// moreProjectFields fetches the remaining fields for any projects
+// that had too many fields to fetch in the original query.
+func (c *Client) moreProjectFields(list []*Project) error {
+	connection := `
+	  fields(first: $PageSize, after: $Cursor) {
+	    pageInfo {
+	      hasNextPage
+	      endCursor
+	    }
+	    totalCount
+	    nodes {
+	      ` + projectFieldFields + `
+	    }
+	  }
+	`
+
+	for _, p := range list {
+		if p.moreFields == "" {
+			continue
+		}
+		fields, err := collectMore(c, p.ID, "ProjectV2", p.moreFields, connection, toProjectField,
+			func(sp *schema.ProjectV2) pager[schema.ProjectV2FieldConfiguration] {
+				if sp.Fields == nil {
+					return nil
+				}
+				return sp.Fields
+			},
+		)
+		p.Fields = append(p.Fields, fields...)
+		if err != nil {
+			return err
+		}
+		p.moreFields = ""
+	}
+	return nil
+}
+
 type ProjectField struct {
 	Kind       string // "field", "iteration", "select"
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
-	DataType   schema.ProjectV2FieldType // TODO
+	DataType   schema.ProjectV2FieldType
 	DatabaseID int
 	ID         schema.ID
 	Name       string
@@ -251,6 +552,7 @@ func toProjectField(su schema.ProjectV2FieldConfiguration) *ProjectField {
 	f := &ProjectField{
 		CreatedAt:  toTime(s.GetCreatedAt()),
 		UpdatedAt:  toTime(s.GetUpdatedAt()),
+		DataType:   s.GetDataType(),
 		DatabaseID: s.GetDatabaseId(),
 		ID:         s.GetId(),
 		Name:       s.GetName(),
@@ -321,14 +623,18 @@ func toProjectFieldOption(s *schema.ProjectV2SingleSelectFieldOption) *ProjectFi
 }
 
 type ProjectItem struct {
-	CreatedAt  time.Time
-	DatabaseID int
-	ID         schema.ID
-	IsArchived bool
-	Type       schema.ProjectV2ItemType
-	UpdatedAt  time.Time
-	Fields     []*ProjectFieldValue
-	Issue      *Issue
+	CreatedAt   time.Time
+	DatabaseID  int
+	ID          schema.ID
+	IsArchived  bool
+	Type        schema.ProjectV2ItemType
+	UpdatedAt   time.Time
+	Fields      []*ProjectFieldValue
+	Issue       *Issue
+	PullRequest *PullRequest
+	DraftIssue  *DraftIssue
+
+	moreFields string // cursor for fetching remaining field values
 }
 
 func (it *ProjectItem) FieldByName(name string) *ProjectFieldValue {
@@ -341,7 +647,6 @@ func (it *ProjectItem) FieldByName(name string) *ProjectFieldValue {
 }
 
 func (p *Project) toProjectItem(s *schema.ProjectV2Item) *ProjectItem {
-	// TODO: Check p.Fields.PageInfo.HasNextPage.
 	it := &ProjectItem{
 		CreatedAt:  toTime(s.CreatedAt),
 		DatabaseID: s.DatabaseId,
@@ -350,14 +655,98 @@ func (p *Project) toProjectItem(s *schema.ProjectV2Item) *ProjectItem {
 		Type:       s.Type,
 		UpdatedAt:  toTime(s.UpdatedAt),
 		Fields:     apply(p.toProjectFieldValue, s.FieldValues.Nodes),
-		// TODO Issue
 	}
-	if si, ok := s.Content.Interface.(*schema.Issue); ok {
-		it.Issue = toIssue(si)
+	switch sc := s.Content.Interface.(type) {
+	case *schema.Issue:
+		it.Issue = toIssue(sc)
+	case *schema.PullRequest:
+		it.PullRequest = toPullRequest(sc)
+	case *schema.DraftIssue:
+		it.DraftIssue = toDraftIssue(sc)
+	}
+	if s.FieldValues.PageInfo.HasNextPage {
+		it.moreFields = s.FieldValues.PageInfo.EndCursor
 	}
 	return it
 }
 
+// moreProjectItemFields fetches the remaining field values for any items
+// that had too many field values to fetch in the original query.
+func (c *Client) moreProjectItemFields(p *Project, list []*ProjectItem) error {
+	connection := `
+	  fieldValues(first: $PageSize, after: $Cursor) {
+	    pageInfo {
+	      hasNextPage
+	      endCursor
+	    }
+	    totalCount
+	    nodes {
+	      ` + projectFieldValueFields + `
+	    }
+	  }
+	`
+
+	var issues []*Issue
+	var prs []*PullRequest
+	for _, it := range list {
+		if it.Issue != nil {
+			issues = append(issues, it.Issue)
+		}
+		if it.PullRequest != nil {
+			prs = append(prs, it.PullRequest)
+		}
+		if it.moreFields == "" {
+			continue
+		}
+		fields, err := collectMore(c, string(it.ID), "ProjectV2Item", it.moreFields, connection, p.toProjectFieldValue,
+			func(si *schema.ProjectV2Item) pager[schema.ProjectV2ItemFieldValue] {
+				if si.FieldValues == nil {
+					return nil
+				}
+				return si.FieldValues
+			},
+		)
+		it.Fields = append(it.Fields, fields...)
+		if err != nil {
+			return err
+		}
+		it.moreFields = ""
+	}
+	if err := c.morePullRequestLabels(prs); err != nil {
+		return err
+	}
+	return c.moreIssueLabels(issues)
+}
+
+const draftIssueFields = `
+  id
+  title
+  body
+  createdAt
+  updatedAt
+  creator { __typename login }
+`
+
+type DraftIssue struct {
+	ID        string
+	Title     string
+	Body      string
+	Author    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func toDraftIssue(s *schema.DraftIssue) *DraftIssue {
+	return &DraftIssue{
+		ID:        string(s.Id),
+		Title:     s.Title,
+		Body:      s.Body,
+		Author:    toAuthor(&s.Creator),
+		CreatedAt: toTime(s.CreatedAt),
+		UpdatedAt: toTime(s.UpdatedAt),
+	}
+}
+
 type ProjectFieldValue struct {
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
@@ -368,6 +757,8 @@ type ProjectFieldValue struct {
 	Option     *ProjectFieldOption
 	Date       time.Time
 	Text       string
+	Number     float64
+	Iteration  *ProjectIteration
 }
 
 func (v *ProjectFieldValue) String() string {
@@ -378,6 +769,12 @@ func (v *ProjectFieldValue) String() string {
 		return fmt.Sprintf("%s:%q", v.Field, v.Text)
 	case "select":
 		return fmt.Sprintf("%s:%q", v.Field, v.Option)
+	case "number":
+		return fmt.Sprintf("%s:%v", v.Field, v.Number)
+	case "iteration":
+		if v.Iteration != nil {
+			return fmt.Sprintf("%s:%q", v.Field, v.Iteration.Title)
+		}
 	}
 	return fmt.Sprintf("%s:???", v.Field)
 }
@@ -413,6 +810,13 @@ func (p *Project) toProjectFieldValue(s schema.ProjectV2ItemFieldValue) *Project
 			Field:      sv.Field.Interface.(schema.ProjectV2FieldCommon_Interface).GetName(),
 			ID:         string(sv.Id),
 			UpdatedAt:  toTime(sv.UpdatedAt),
+			Iteration: &ProjectIteration{
+				Days:      sv.Duration,
+				ID:        sv.IterationId,
+				Start:     toDate(sv.StartDate),
+				Title:     sv.Title,
+				TitleHTML: sv.TitleHTML,
+			},
 		}
 	case *schema.ProjectV2ItemFieldLabelValue:
 		return &ProjectFieldValue{
@@ -432,6 +836,7 @@ func (p *Project) toProjectFieldValue(s schema.ProjectV2ItemFieldValue) *Project
 			Field:      sv.Field.Interface.(schema.ProjectV2FieldCommon_Interface).GetName(),
 			ID:         string(sv.Id),
 			UpdatedAt:  toTime(sv.UpdatedAt),
+			Number:     sv.Number,
 		}
 	case *schema.ProjectV2ItemFieldPullRequestValue:
 		return &ProjectFieldValue{
@@ -0,0 +1,210 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"rsc.io/github/schema"
+)
+
+const branchProtectionRuleFields = `
+  id
+  pattern
+  allowsDeletions
+  allowsForcePushes
+  blocksCreations
+  dismissesStaleReviews
+  isAdminEnforced
+  requiredApprovingReviewCount
+  requiresApprovingReviews
+  requiresCodeOwnerReviews
+  requiresCommitSignatures
+  requiresConversationResolution
+  requiresLinearHistory
+  requiresStatusChecks
+  requiresStrictStatusChecks
+  restrictsPushes
+  restrictsReviewDismissals
+  requiredStatusChecks {
+    context
+    app { id }
+  }
+`
+
+// A BranchProtectionRule describes the protections applied
+// to the branches in a repository with names matching Pattern.
+type BranchProtectionRule struct {
+	ID      string
+	Pattern string
+
+	AllowsDeletions                bool
+	AllowsForcePushes              bool
+	BlocksCreations                bool
+	DismissesStaleReviews          bool
+	IsAdminEnforced                bool
+	RequiredApprovingReviewCount   int
+	RequiresApprovingReviews       bool
+	RequiresCodeOwnerReviews       bool
+	RequiresCommitSignatures       bool
+	RequiresConversationResolution bool
+	RequiresLinearHistory          bool
+	RequiresStatusChecks           bool
+	RequiresStrictStatusChecks     bool
+	RestrictsPushes                bool
+	RestrictsReviewDismissals      bool
+	RequiredStatusChecks           []*RequiredStatusCheck
+}
+
+// A RequiredStatusCheck is a status check that must pass before merging.
+type RequiredStatusCheck struct {
+	Context string
+	AppID   string // ID of app that must set the status, or "" for any
+}
+
+func toBranchProtectionRule(s *schema.BranchProtectionRule) *BranchProtectionRule {
+	r := &BranchProtectionRule{
+		ID:                             string(s.Id),
+		Pattern:                        s.Pattern,
+		AllowsDeletions:                s.AllowsDeletions,
+		AllowsForcePushes:              s.AllowsForcePushes,
+		BlocksCreations:                s.BlocksCreations,
+		DismissesStaleReviews:          s.DismissesStaleReviews,
+		IsAdminEnforced:                s.IsAdminEnforced,
+		RequiredApprovingReviewCount:   s.RequiredApprovingReviewCount,
+		RequiresApprovingReviews:       s.RequiresApprovingReviews,
+		RequiresCodeOwnerReviews:       s.RequiresCodeOwnerReviews,
+		RequiresCommitSignatures:       s.RequiresCommitSignatures,
+		RequiresConversationResolution: s.RequiresConversationResolution,
+		RequiresLinearHistory:          s.RequiresLinearHistory,
+		RequiresStatusChecks:           s.RequiresStatusChecks,
+		RequiresStrictStatusChecks:     s.RequiresStrictStatusChecks,
+		RestrictsPushes:                s.RestrictsPushes,
+		RestrictsReviewDismissals:      s.RestrictsReviewDismissals,
+	}
+	for _, sc := range s.RequiredStatusChecks {
+		check := &RequiredStatusCheck{Context: sc.Context}
+		if sc.App != nil {
+			check.AppID = string(sc.App.Id)
+		}
+		r.RequiredStatusChecks = append(r.RequiredStatusChecks, check)
+	}
+	return r
+}
+
+// input returns the GraphQL input object fields for creating or updating r.
+func (r *BranchProtectionRule) input() map[string]any {
+	checks := []map[string]any{}
+	for _, sc := range r.RequiredStatusChecks {
+		check := map[string]any{"context": sc.Context}
+		if sc.AppID != "" {
+			check["appId"] = sc.AppID
+		}
+		checks = append(checks, check)
+	}
+	return map[string]any{
+		"pattern":                        r.Pattern,
+		"allowsDeletions":                r.AllowsDeletions,
+		"allowsForcePushes":              r.AllowsForcePushes,
+		"blocksCreations":                r.BlocksCreations,
+		"dismissesStaleReviews":          r.DismissesStaleReviews,
+		"isAdminEnforced":                r.IsAdminEnforced,
+		"requiredApprovingReviewCount":   r.RequiredApprovingReviewCount,
+		"requiresApprovingReviews":       r.RequiresApprovingReviews,
+		"requiresCodeOwnerReviews":       r.RequiresCodeOwnerReviews,
+		"requiresCommitSignatures":       r.RequiresCommitSignatures,
+		"requiresConversationResolution": r.RequiresConversationResolution,
+		"requiresLinearHistory":          r.RequiresLinearHistory,
+		"requiresStatusChecks":           r.RequiresStatusChecks,
+		"requiresStrictStatusChecks":     r.RequiresStrictStatusChecks,
+		"restrictsPushes":                r.RestrictsPushes,
+		"restrictsReviewDismissals":      r.RestrictsReviewDismissals,
+		"requiredStatusChecks":           checks,
+	}
+}
+
+func (c *Client) BranchProtectionRules(org, repo string) ([]*BranchProtectionRule, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      branchProtectionRules(first: $PageSize, after: $Cursor) {
+	        pageInfo {
+	          hasNextPage
+	          endCursor
+	        }
+	        totalCount
+	        nodes {
+	          ` + branchProtectionRuleFields + `
+	        }
+	      }
+	    }
+	  }
+	`
+
+	vars := Vars{"Org": org, "Repo": repo}
+	return collect(c, graphql, vars, toBranchProtectionRule,
+		func(q *schema.Query) pager[*schema.BranchProtectionRule] { return q.Repository.BranchProtectionRules },
+	)
+}
+
+// CreateBranchProtectionRule adds the protection rule r to the repository.
+// The ID field of r is ignored.
+func (c *Client) CreateBranchProtectionRule(repo *Repo, r *BranchProtectionRule) (*BranchProtectionRule, error) {
+	graphql := `
+	  mutation($Input: CreateBranchProtectionRuleInput!) {
+	    createBranchProtectionRule(input: $Input) {
+	      clientMutationId
+	      branchProtectionRule {
+	        ` + branchProtectionRuleFields + `
+	      }
+	    }
+	  }
+	`
+	input := r.input()
+	input["repositoryId"] = repo.ID
+	m, err := c.GraphQLMutation(graphql, Vars{"Input": input})
+	if err != nil {
+		return nil, err
+	}
+	if m.CreateBranchProtectionRule == nil || m.CreateBranchProtectionRule.BranchProtectionRule == nil {
+		return nil, c.noResult("createBranchProtectionRule")
+	}
+	return toBranchProtectionRule(m.CreateBranchProtectionRule.BranchProtectionRule), nil
+}
+
+// UpdateBranchProtectionRule replaces the settings of the existing rule
+// identified by r.ID with the settings in r.
+func (c *Client) UpdateBranchProtectionRule(r *BranchProtectionRule) (*BranchProtectionRule, error) {
+	graphql := `
+	  mutation($Input: UpdateBranchProtectionRuleInput!) {
+	    updateBranchProtectionRule(input: $Input) {
+	      clientMutationId
+	      branchProtectionRule {
+	        ` + branchProtectionRuleFields + `
+	      }
+	    }
+	  }
+	`
+	input := r.input()
+	input["branchProtectionRuleId"] = r.ID
+	m, err := c.GraphQLMutation(graphql, Vars{"Input": input})
+	if err != nil {
+		return nil, err
+	}
+	if m.UpdateBranchProtectionRule == nil || m.UpdateBranchProtectionRule.BranchProtectionRule == nil {
+		return nil, c.noResult("updateBranchProtectionRule")
+	}
+	return toBranchProtectionRule(m.UpdateBranchProtectionRule.BranchProtectionRule), nil
+}
+
+func (c *Client) DeleteBranchProtectionRule(r *BranchProtectionRule) error {
+	graphql := `
+	  mutation($Rule: ID!) {
+	    deleteBranchProtectionRule(input: {branchProtectionRuleId: $Rule}) {
+	      clientMutationId
+	    }
+	  }
+	`
+	_, err := c.GraphQLMutation(graphql, Vars{"Rule": r.ID})
+	return err
+}
@@ -0,0 +1,258 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"fmt"
+	"time"
+
+	"rsc.io/github/schema"
+)
+
+const pullRequestFields = `
+  number
+  title
+  id
+  author { __typename login }
+  closed
+  closedAt
+  createdAt
+  lastEditedAt
+  merged
+  mergedAt
+  isDraft
+  baseRefName
+  headRefName
+  headRefOid
+  autoMergeRequest { mergeMethod }
+  reviewDecision
+  mergeable
+  commits(last: 1) {
+    nodes {
+      commit { statusCheckRollup { state } }
+    }
+  }
+  milestone { id number title }
+  repository { name owner { __typename login } }
+  body
+  url
+  labels(first: 100) {
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+    nodes {
+      name
+      description
+      id
+      repository { name owner { __typename login } }
+    }
+  }
+`
+
+type PullRequest struct {
+	ID           string
+	Title        string
+	Number       int
+	Closed       bool
+	ClosedAt     time.Time
+	CreatedAt    time.Time
+	LastEditedAt time.Time
+	Merged       bool
+	MergedAt     time.Time
+	Draft        bool
+	BaseRef      string
+	HeadRef      string
+	HeadCommit   string
+	AutoMerge    schema.PullRequestMergeMethod // merge method if auto-merge is enabled
+
+	// ReviewDecision is APPROVED, CHANGES_REQUESTED, or REVIEW_REQUIRED,
+	// or empty if reviews are not required.
+	ReviewDecision schema.PullRequestReviewDecision
+
+	// Mergeable is MERGEABLE, CONFLICTING, or UNKNOWN.
+	Mergeable schema.MergeableState
+
+	// Checks is the combined state of the head commit's checks and statuses:
+	// SUCCESS, FAILURE, PENDING, ERROR, or EXPECTED.
+	// It is empty if the commit has no checks or statuses.
+	Checks schema.StatusState
+
+	// MergeState summarizes whether the pull request can be merged:
+	// CLEAN, BLOCKED, BEHIND, DIRTY, DRAFT, HAS_HOOKS, UNKNOWN, or UNSTABLE.
+	// It is newer than the schema package, so it is set only by Client.PullRequest
+	// and is empty in pull requests returned by other queries.
+	MergeState string
+
+	Labels    []*Label
+	Milestone *Milestone
+	Author    string
+	Owner     string
+	Repo      string
+	Body      string
+	URL       string
+
+	moreLabels string // cursor for fetching remaining labels
+}
+
+func toPullRequest(s *schema.PullRequest) *PullRequest {
+	p := &PullRequest{
+		ID:           string(s.Id),
+		Title:        s.Title,
+		Number:       s.Number,
+		Author:       toAuthor(&s.Author),
+		Closed:       s.Closed,
+		ClosedAt:     toTime(s.ClosedAt),
+		CreatedAt:    toTime(s.CreatedAt),
+		LastEditedAt: toTime(s.LastEditedAt),
+		Merged:       s.Merged,
+		MergedAt:     toTime(s.MergedAt),
+		Draft:        s.IsDraft,
+		BaseRef:      s.BaseRefName,
+		HeadRef:      s.HeadRefName,
+		HeadCommit:   string(s.HeadRefOid),
+		Owner:        toOwner(&s.Repository.Owner),
+		Repo:         s.Repository.Name,
+		Milestone:    toMilestone(s.Milestone),
+		Labels:       apply(toLabel, s.Labels.Nodes),
+		Body:         s.Body,
+		URL:          string(s.Url),
+	}
+	if s.AutoMergeRequest != nil {
+		p.AutoMerge = s.AutoMergeRequest.MergeMethod
+	}
+	if s.Labels != nil && s.Labels.PageInfo.HasNextPage {
+		p.moreLabels = s.Labels.PageInfo.EndCursor
+	}
+	p.ReviewDecision = s.ReviewDecision
+	p.Mergeable = s.Mergeable
+	if s.Commits != nil {
+		for _, pc := range s.Commits.Nodes {
+			if pc.Commit != nil && pc.Commit.StatusCheckRollup != nil {
+				p.Checks = pc.Commit.StatusCheckRollup.State
+			}
+		}
+	}
+	return p
+}
+
+// morePullRequestLabels fetches the remaining labels for any pull requests
+// that had too many labels to fetch in the original query.
+func (c *Client) morePullRequestLabels(list []*PullRequest) error {
+	connection := `
+	  labels(first: $PageSize, after: $Cursor) {
+	    pageInfo {
+	      hasNextPage
+	      endCursor
+	    }
+	    totalCount
+	    nodes {
+	      name
+	      description
+	      id
+	      repository { name owner { __typename login } }
+	    }
+	  }
+	`
+
+	for _, pr := range list {
+		if pr.moreLabels == "" {
+			continue
+		}
+		labels, err := collectMore(c, pr.ID, "PullRequest", pr.moreLabels, connection, toLabel,
+			func(sp *schema.PullRequest) pager[*schema.Label] {
+				if sp.Labels == nil {
+					return nil
+				}
+				return sp.Labels
+			},
+		)
+		pr.Labels = append(pr.Labels, labels...)
+		if err != nil {
+			return err
+		}
+		pr.moreLabels = ""
+	}
+	return nil
+}
+
+// PullRequest returns the pull request in org/repo with number n.
+// Unlike pull requests returned by other queries, it sets MergeState.
+func (c *Client) PullRequest(org, repo string, n int) (*PullRequest, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Number: Int!) {
+	    repository(owner: $Org, name: $Repo) {
+	      pullRequest(number: $Number) {
+	        ` + pullRequestFields + `
+	        mergeStateStatus
+	      }
+	    }
+	  }
+	`
+
+	var reply struct {
+		Repository *struct {
+			PullRequest *struct {
+				schema.PullRequest
+				MergeStateStatus string
+			}
+		}
+	}
+	if err := c.graphQL(graphql, Vars{"Org": org, "Repo": repo, "Number": n}, &reply); err != nil {
+		return nil, err
+	}
+	if reply.Repository == nil || reply.Repository.PullRequest == nil {
+		return nil, fmt.Errorf("%s/%s#%d: pull request not found", org, repo, n)
+	}
+	p := toPullRequest(&reply.Repository.PullRequest.PullRequest)
+	p.MergeState = reply.Repository.PullRequest.MergeStateStatus
+	if err := c.morePullRequestLabels([]*PullRequest{p}); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *PullRequest) LabelByName(name string) *Label {
+	for _, lab := range p.Labels {
+		if lab.Name == name {
+			return lab
+		}
+	}
+	return nil
+}
+
+// EnablePullRequestAutoMerge enables auto-merge for the pull request,
+// so that it is merged using method (MERGE, SQUASH, or REBASE)
+// once all its requirements are met.
+func (c *Client) EnablePullRequestAutoMerge(pr *PullRequest, method schema.PullRequestMergeMethod) error {
+	graphql := `
+	  mutation($ID: ID!, $Method: PullRequestMergeMethod) {
+	    enablePullRequestAutoMerge(input: {pullRequestId: $ID, mergeMethod: $Method}) {
+	      clientMutationId
+	    }
+	  }
+	`
+	_, err := c.GraphQLMutation(graphql, Vars{"ID": pr.ID, "Method": method})
+	if err == nil {
+		pr.AutoMerge = method
+	}
+	return err
+}
+
+// DisablePullRequestAutoMerge disables auto-merge for the pull request.
+func (c *Client) DisablePullRequestAutoMerge(pr *PullRequest) error {
+	graphql := `
+	  mutation($ID: ID!) {
+	    disablePullRequestAutoMerge(input: {pullRequestId: $ID}) {
+	      clientMutationId
+	    }
+	  }
+	`
+	_, err := c.GraphQLMutation(graphql, Vars{"ID": pr.ID})
+	if err == nil {
+		pr.AutoMerge = ""
+	}
+	return err
+}
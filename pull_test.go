@@ -0,0 +1,30 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github_test
+
+import (
+	"testing"
+
+	"rsc.io/github/githubtest"
+)
+
+func TestPullRequestMoreLabels(t *testing.T) {
+	// The pull request has three labels but the first query returns only one.
+	c := githubtest.Client(t, "testdata/prlabels.json")
+	pr, err := c.PullRequest("rsc", "quote", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, lab := range pr.Labels {
+		names = append(names, lab.Name)
+	}
+	if len(names) != 3 || names[0] != "a" || names[1] != "b" || names[2] != "c" {
+		t.Errorf("labels = %v, want [a b c]", names)
+	}
+	if pr.MergeState != "CLEAN" {
+		t.Errorf("MergeState = %q, want CLEAN", pr.MergeState)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"fmt"
+	"time"
+
+	"rsc.io/github/schema"
+)
+
+// Merge queues are newer than the schema package,
+// so the queries in this file decode into local types.
+
+const mergeQueueEntryFields = `
+  id
+  position
+  state
+  enqueuedAt
+  estimatedTimeToMerge
+  jump
+  solo
+  enqueuer { login }
+  headCommit { oid }
+  pullRequest { number title url }
+`
+
+// A MergeQueueEntry is a pull request waiting in a merge queue.
+type MergeQueueEntry struct {
+	ID            string
+	Position      int    // 0 is the head of the queue
+	State         string // QUEUED, AWAITING_CHECKS, MERGEABLE, UNMERGEABLE, or LOCKED
+	EnqueuedAt    time.Time
+	EstimatedTime time.Duration // estimated time until merge; 0 if unknown
+	Jump          bool          // entry was added to the head of the queue
+	Solo          bool          // entry is merged by itself, not grouped with others
+	Enqueuer      string
+	HeadCommit    string
+	PullRequest   int // pull request number
+	Title         string
+	URL           string
+}
+
+type mergeQueueEntryJSON struct {
+	ID                   string
+	Position             int
+	State                string
+	EnqueuedAt           schema.DateTime
+	EstimatedTimeToMerge int // seconds
+	Jump                 bool
+	Solo                 bool
+	Enqueuer             *struct{ Login string }
+	HeadCommit           *struct{ Oid string }
+	PullRequest          *struct {
+		Number int
+		Title  string
+		URL    string
+	}
+}
+
+func toMergeQueueEntry(j *mergeQueueEntryJSON) *MergeQueueEntry {
+	e := &MergeQueueEntry{
+		ID:            j.ID,
+		Position:      j.Position,
+		State:         j.State,
+		EnqueuedAt:    toTime(j.EnqueuedAt),
+		EstimatedTime: time.Duration(j.EstimatedTimeToMerge) * time.Second,
+		Jump:          j.Jump,
+		Solo:          j.Solo,
+	}
+	if j.Enqueuer != nil {
+		e.Enqueuer = j.Enqueuer.Login
+	}
+	if j.HeadCommit != nil {
+		e.HeadCommit = j.HeadCommit.Oid
+	}
+	if j.PullRequest != nil {
+		e.PullRequest = j.PullRequest.Number
+		e.Title = j.PullRequest.Title
+		e.URL = j.PullRequest.URL
+	}
+	return e
+}
+
+// MergeQueue returns the entries in the merge queue for the branch in org/repo,
+// in queue order. If branch is the empty string, MergeQueue uses the default branch.
+// If the branch has no merge queue, MergeQueue returns an empty list.
+func (c *Client) MergeQueue(org, repo, branch string) ([]*MergeQueueEntry, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Branch: String, $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      mergeQueue(branch: $Branch) {
+	        entries(first: $PageSize, after: $Cursor) {
+	          pageInfo {
+	            hasNextPage
+	            endCursor
+	          }
+	          totalCount
+	          nodes {
+	            ` + mergeQueueEntryFields + `
+	          }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	type reply struct {
+		Repository *struct {
+			MergeQueue *struct {
+				Entries *connection[*mergeQueueEntryJSON]
+			}
+		}
+	}
+	vars := Vars{"Org": org, "Repo": repo}
+	if branch != "" {
+		vars["Branch"] = branch
+	}
+	return collectReply(c, graphql, vars, toMergeQueueEntry,
+		func(r *reply) pager[*mergeQueueEntryJSON] {
+			if r.Repository == nil || r.Repository.MergeQueue == nil || r.Repository.MergeQueue.Entries == nil {
+				return nil
+			}
+			return r.Repository.MergeQueue.Entries
+		},
+	)
+}
+
+// PullRequestQueueEntry returns the pull request's merge queue entry,
+// or nil if the pull request is not in a merge queue.
+func (c *Client) PullRequestQueueEntry(pr *PullRequest) (*MergeQueueEntry, error) {
+	graphql := `
+	  query($ID: ID!) {
+	    node(id: $ID) {
+	      __typename
+	      ... on PullRequest {
+	        mergeQueueEntry {
+	          ` + mergeQueueEntryFields + `
+	        }
+	      }
+	    }
+	  }
+	`
+
+	var reply struct {
+		Node *struct {
+			MergeQueueEntry *mergeQueueEntryJSON
+		}
+	}
+	if err := c.graphQL(graphql, Vars{"ID": pr.ID}, &reply); err != nil {
+		return nil, err
+	}
+	if reply.Node == nil {
+		return nil, fmt.Errorf("%s/%s#%d: pull request not found", pr.Owner, pr.Repo, pr.Number)
+	}
+	if reply.Node.MergeQueueEntry == nil {
+		return nil, nil
+	}
+	return toMergeQueueEntry(reply.Node.MergeQueueEntry), nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"rsc.io/github/schema"
+)
+
+// A RateLimit describes the state of the GraphQL API rate limit,
+// which GitHub measures in points per hour.
+type RateLimit struct {
+	Limit     int       // points allowed per hour
+	Remaining int       // points remaining until reset
+	Used      int       // points used since last reset
+	ResetAt   time.Time // time of next reset
+	Cost      int       // cost of the most recent query; 0 if unknown or a mutation
+}
+
+// rateState is the rate limit state shared by a Client and its copies.
+type rateState struct {
+	mu   sync.Mutex
+	last *RateLimit
+}
+
+// RateLimit returns the GraphQL API rate limit as of the most recent
+// request made by c or any of the copies made by its With methods.
+// It returns nil if no requests have been made.
+//
+// The GraphQL and REST APIs are limited separately;
+// RateLimit reports only the GraphQL limit.
+func (c *Client) RateLimit() *RateLimit {
+	if c.rate == nil {
+		return nil
+	}
+	c.rate.mu.Lock()
+	defer c.rate.mu.Unlock()
+	if c.rate.last == nil {
+		return nil
+	}
+	rl := *c.rate.last
+	return &rl
+}
+
+func (c *Client) setRateLimit(rl *RateLimit) {
+	if rl == nil {
+		return
+	}
+	if c.metrics != nil {
+		c.metrics.SetRateLimitRemaining(rl.Remaining)
+	}
+	if c.rate == nil {
+		return
+	}
+	c.rate.mu.Lock()
+	c.rate.last = rl
+	c.rate.mu.Unlock()
+}
+
+const rateLimitField = ` rateLimit { cost limit remaining used resetAt }`
+
+// addRateLimit returns query with a rateLimit field added to its top-level selection,
+// so that the reply reports the query's cost.
+// It reports whether it added the field: it does not if the query
+// already requests rateLimit or is not a query operation.
+func addRateLimit(query string) (string, bool) {
+	t := strings.TrimSpace(query)
+	if strings.Contains(query, "rateLimit") || !strings.HasPrefix(t, "query") && !strings.HasPrefix(t, "{") {
+		return query, false
+	}
+	depth := 0
+	for i := 0; i < len(query); i++ {
+		switch query[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '{':
+			if depth == 0 {
+				return query[:i+1] + rateLimitField + query[i+1:], true
+			}
+		}
+	}
+	return query, false
+}
+
+// parseRateLimit returns the rate limit reported by a GraphQL response.
+// If the query included the rateLimit field (see addRateLimit),
+// data is the JSON response body; otherwise data is nil and
+// parseRateLimit uses the X-RateLimit headers.
+// It returns nil if the response has no rate limit information.
+func parseRateLimit(resp *http.Response, data []byte) *RateLimit {
+	if data != nil {
+		var reply struct {
+			Data struct {
+				RateLimit *schema.RateLimit
+			}
+		}
+		if json.Unmarshal(data, &reply) == nil && reply.Data.RateLimit != nil {
+			r := reply.Data.RateLimit
+			return &RateLimit{
+				Limit:     r.Limit,
+				Remaining: r.Remaining,
+				Used:      r.Used,
+				ResetAt:   toTime(r.ResetAt),
+				Cost:      r.Cost,
+			}
+		}
+	}
+
+	h := resp.Header
+	if h.Get("X-RateLimit-Remaining") == "" {
+		return nil
+	}
+	atoi := func(key string) int {
+		n, _ := strconv.Atoi(h.Get(key))
+		return n
+	}
+	rl := &RateLimit{
+		Limit:     atoi("X-RateLimit-Limit"),
+		Remaining: atoi("X-RateLimit-Remaining"),
+		Used:      atoi("X-RateLimit-Used"),
+	}
+	if reset := atoi("X-RateLimit-Reset"); reset != 0 {
+		rl.ResetAt = time.Unix(int64(reset), 0)
+	}
+	return rl
+}
+
+// A RateLimitError reports that a request failed because it exceeded a GitHub rate limit.
+// Clients retry such requests according to their [RetryPolicy],
+// returning a RateLimitError only when the policy gives up.
+type RateLimitError struct {
+	Message   string        // message from GitHub
+	Secondary bool          // exceeded a secondary (abuse) rate limit, not the primary hourly limit
+	Wait      time.Duration // how long GitHub asked the client to wait before retrying
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s (retry after %v)", e.Message, e.Wait)
+}
+
+// checkRateLimit checks whether a failed request failed because of a rate limit.
+// If so, it returns a RateLimitError describing how long to wait,
+// as indicated by the Retry-After and X-RateLimit-Reset headers.
+// The msg is the error message from the response.
+func checkRateLimit(resp *http.Response, msg string) *RateLimitError {
+	h := resp.Header
+	limited := resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests
+	secondary := strings.Contains(msg, "secondary rate limit") ||
+		strings.Contains(msg, "submitted too quickly") ||
+		strings.Contains(msg, "wait a few minutes")
+	primary := strings.Contains(msg, "rate limit exceeded") ||
+		limited && h.Get("X-RateLimit-Remaining") == "0"
+	if !secondary && !primary && !(limited && h.Get("Retry-After") != "") {
+		return nil
+	}
+
+	e := &RateLimitError{Message: msg, Secondary: !primary}
+	if ra := h.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil {
+			e.Wait = time.Duration(secs) * time.Second
+		} else if t, err := http.ParseTime(ra); err == nil {
+			e.Wait = time.Until(t)
+		}
+	} else if h.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			// Wait an extra second to allow for clock skew.
+			e.Wait = time.Until(time.Unix(reset, 0)) + time.Second
+		}
+	}
+	if e.Wait <= 0 {
+		// No usable headers.
+		// GitHub recommends waiting at least a minute after a secondary rate limit.
+		if e.Secondary {
+			e.Wait = 1 * time.Minute
+		} else {
+			e.Wait = 10 * time.Minute
+		}
+	}
+	return e
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// shortHosts maps the hosts of short issue links to the repositories they refer to.
+var shortHosts = map[string]string{
+	"go.dev":     "golang/go",
+	"golang.org": "golang/go",
+}
+
+// ParseIssueURL parses an issue or pull request URL,
+// such as "https://github.com/golang/go/issues/123",
+// returning the organization, repository, and number.
+// It accepts pull request and discussion URLs (".../pull/123", ".../discussions/123"),
+// URLs with fragments (".../issues/123#issuecomment-456"),
+// URLs for GitHub Enterprise Server hosts,
+// URLs without a scheme ("github.com/golang/go/issues/123"),
+// and the Go project's short links ("https://go.dev/issue/123" or "golang.org/issue/123").
+func ParseIssueURL(s string) (org, repo string, number int, err error) {
+	bad := func() (string, string, int, error) {
+		return "", "", 0, fmt.Errorf("invalid issue URL %q", s)
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return bad()
+	}
+	if u.Host == "" && u.Scheme == "" {
+		// No scheme: "github.com/golang/go/issues/123".
+		host, path, _ := strings.Cut(u.Path, "/")
+		u.Host, u.Path = host, "/"+path
+	}
+	if u.Host == "" || u.Scheme != "" && u.Scheme != "https" && u.Scheme != "http" {
+		return bad()
+	}
+	f := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if r := shortHosts[u.Host]; r != "" && len(f) == 2 && f[0] == "issue" {
+		org, repo, _ = strings.Cut(r, "/")
+		f = []string{org, repo, "issues", f[1]}
+	}
+	if len(f) != 4 || f[0] == "" || f[1] == "" || f[2] != "issues" && f[2] != "pull" && f[2] != "discussions" {
+		return bad()
+	}
+	n, err := strconv.Atoi(f[3])
+	if err != nil || n <= 0 {
+		return bad()
+	}
+	return f[0], f[1], n, nil
+}
+
+// ParseRef parses a reference to an issue or pull request,
+// returning the organization, repository, and number.
+// The reference can be a URL accepted by [ParseIssueURL]
+// or a GitHub cross-reference of the form "org/repo#123".
+func ParseRef(s string) (org, repo string, number int, err error) {
+	s = strings.TrimSpace(s)
+	if path, num, ok := strings.Cut(s, "#"); ok && !strings.Contains(path, ":") && strings.Count(path, "/") == 1 {
+		org, repo, _ := strings.Cut(path, "/")
+		n, err := strconv.Atoi(num)
+		if org == "" || repo == "" || err != nil || n <= 0 {
+			return "", "", 0, fmt.Errorf("invalid issue reference %q", s)
+		}
+		return org, repo, n, nil
+	}
+	org, repo, number, err = ParseIssueURL(s)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid issue reference %q", s)
+	}
+	return org, repo, number, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github_test
+
+import (
+	"testing"
+
+	"rsc.io/github"
+)
+
+var parseIssueURLTests = []struct {
+	in     string
+	org    string
+	repo   string
+	number int
+}{
+	{"https://github.com/golang/go/issues/123", "golang", "go", 123},
+	{"https://github.com/golang/go/issues/123/", "golang", "go", 123},
+	{"http://github.com/golang/go/issues/123", "golang", "go", 123},
+	{"https://github.com/golang/go/pull/45", "golang", "go", 45},
+	{"https://github.com/golang/go/discussions/67", "golang", "go", 67},
+	{"https://github.com/golang/go/issues/123#issuecomment-456", "golang", "go", 123},
+	{"https://github.com/golang/go/issues/123?q=x", "golang", "go", 123},
+	{"https://ghe.example.com/team/tools/issues/9", "team", "tools", 9},
+	{"github.com/golang/go/issues/123", "golang", "go", 123},
+	{"https://go.dev/issue/123", "golang", "go", 123},
+	{"golang.org/issue/123", "golang", "go", 123},
+
+	{"", "", "", 0},
+	{"123", "", "", 0},
+	{"golang/go#123", "", "", 0},
+	{"ftp://github.com/golang/go/issues/123", "", "", 0},
+	{"https://github.com/golang/go", "", "", 0},
+	{"https://github.com/golang/go/issues", "", "", 0},
+	{"https://github.com/golang/go/issues/0", "", "", 0},
+	{"https://github.com/golang/go/issues/-1", "", "", 0},
+	{"https://github.com/golang/go/issues/x", "", "", 0},
+	{"https://github.com/golang/go/commits/123", "", "", 0},
+	{"https://github.com//go/issues/123", "", "", 0},
+	{"https://github.com/golang/go/issues/123/files", "", "", 0},
+	{"https://go.dev/issues/123", "", "", 0},
+	{"https://example.com/issue/123", "", "", 0},
+}
+
+func TestParseIssueURL(t *testing.T) {
+	for _, tt := range parseIssueURLTests {
+		org, repo, n, err := github.ParseIssueURL(tt.in)
+		if tt.number == 0 {
+			if err == nil {
+				t.Errorf("ParseIssueURL(%q) = %q, %q, %d, want error", tt.in, org, repo, n)
+			}
+			continue
+		}
+		if err != nil || org != tt.org || repo != tt.repo || n != tt.number {
+			t.Errorf("ParseIssueURL(%q) = %q, %q, %d, %v, want %q, %q, %d, nil", tt.in, org, repo, n, err, tt.org, tt.repo, tt.number)
+		}
+	}
+}
+
+var parseRefTests = []struct {
+	in     string
+	org    string
+	repo   string
+	number int
+}{
+	{"golang/go#123", "golang", "go", 123},
+	{"  golang/go#123\n", "golang", "go", 123},
+	{"rsc/github#1", "rsc", "github", 1},
+	{"https://github.com/golang/go/issues/123", "golang", "go", 123},
+	{"https://github.com/golang/go/issues/123#issuecomment-456", "golang", "go", 123},
+	{"go.dev/issue/123", "golang", "go", 123},
+
+	{"", "", "", 0},
+	{"#123", "", "", 0},
+	{"golang#123", "", "", 0},
+	{"golang/#123", "", "", 0},
+	{"/go#123", "", "", 0},
+	{"golang/go#", "", "", 0},
+	{"golang/go#0", "", "", 0},
+	{"golang/go#x", "", "", 0},
+	{"golang/go/x#123", "", "", 0},
+}
+
+func TestParseRef(t *testing.T) {
+	for _, tt := range parseRefTests {
+		org, repo, n, err := github.ParseRef(tt.in)
+		if tt.number == 0 {
+			if err == nil {
+				t.Errorf("ParseRef(%q) = %q, %q, %d, want error", tt.in, org, repo, n)
+			}
+			continue
+		}
+		if err != nil || org != tt.org || repo != tt.repo || n != tt.number {
+			t.Errorf("ParseRef(%q) = %q, %q, %d, %v, want %q, %q, %d, nil", tt.in, org, repo, n, err, tt.org, tt.repo, tt.number)
+		}
+	}
+}
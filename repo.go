@@ -0,0 +1,415 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"rsc.io/github/schema"
+)
+
+const repoFields = `
+  id
+  name
+  owner { __typename login }
+  description
+  url
+  visibility
+  isArchived
+  isFork
+  parent { nameWithOwner }
+  hasIssuesEnabled
+  hasProjectsEnabled
+  hasWikiEnabled
+  defaultBranchRef { name }
+  viewerSubscription
+  stargazerCount
+  viewerHasStarred
+  repositoryTopics(first: 100) {
+    nodes {
+      topic { name }
+    }
+  }
+`
+
+type Repo struct {
+	Owner         string
+	Repo          string
+	ID            string
+	Description   string
+	URL           string
+	Visibility    schema.RepositoryVisibility
+	Archived      bool
+	Fork          bool
+	Parent        string // for forks, the owner/repo of the parent repository
+	DefaultBranch string
+	Topics        []string
+	HasIssues     bool
+	HasProjects   bool
+	HasWiki       bool
+	Subscription  schema.SubscriptionState // authenticated user's watch state
+	Stars         int
+	Starred       bool // starred by authenticated user
+}
+
+func toRepo(s *schema.Repository) *Repo {
+	r := &Repo{
+		Owner:        toOwner(&s.Owner),
+		Repo:         s.Name,
+		ID:           string(s.Id),
+		Description:  s.Description,
+		URL:          string(s.Url),
+		Visibility:   s.Visibility,
+		Archived:     s.IsArchived,
+		Fork:         s.IsFork,
+		HasIssues:    s.HasIssuesEnabled,
+		HasProjects:  s.HasProjectsEnabled,
+		HasWiki:      s.HasWikiEnabled,
+		Subscription: s.ViewerSubscription,
+		Stars:        s.StargazerCount,
+		Starred:      s.ViewerHasStarred,
+	}
+	if s.DefaultBranchRef != nil {
+		r.DefaultBranch = s.DefaultBranchRef.Name
+	}
+	if s.Parent != nil {
+		r.Parent = s.Parent.NameWithOwner
+	}
+	if s.RepositoryTopics != nil {
+		for _, t := range s.RepositoryTopics.Nodes {
+			r.Topics = append(r.Topics, t.Topic.Name)
+		}
+	}
+	return r
+}
+
+func (c *Client) Repo(org, repo string) (*Repo, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!) {
+	    repository(owner: $Org, name: $Repo) {
+	      ` + repoFields + `
+	    }
+	  }
+	`
+	vars := Vars{"Org": org, "Repo": repo}
+	q, err := c.GraphQLQuery(graphql, vars)
+	if err != nil {
+		return nil, err
+	}
+	return toRepo(q.Repository), nil
+}
+
+// Repos returns the repositories owned by the organization org.
+func (c *Client) Repos(org string) ([]*Repo, error) {
+	graphql := `
+	  query($Org: String!, $Cursor: String, $PageSize: Int = 100) {
+	    organization(login: $Org) {
+	      repositories(first: $PageSize, after: $Cursor) {
+	        pageInfo {
+	          hasNextPage
+	          endCursor
+	        }
+	        totalCount
+	        nodes {
+	          ` + repoFields + `
+	        }
+	      }
+	    }
+	  }
+	`
+
+	vars := Vars{"Org": org}
+	return collect(c, graphql, vars, toRepo,
+		func(q *schema.Query) pager[*schema.Repository] { return q.Organization.Repositories },
+	)
+}
+
+// CreateRepo creates a new repository named name owned by org.
+func (c *Client) CreateRepo(org, name, description string, visibility schema.RepositoryVisibility) (*Repo, error) {
+	graphql := `
+	  query($Org: String!) {
+	    repositoryOwner(login: $Org) {
+	      __typename
+	      id
+	    }
+	  }
+	`
+	q, err := c.GraphQLQuery(graphql, Vars{"Org": org})
+	if err != nil {
+		return nil, err
+	}
+	owner, ok := q.RepositoryOwner.Interface.(interface{ GetId() schema.ID })
+	if !ok {
+		return nil, fmt.Errorf("cannot find owner %s", org)
+	}
+
+	graphql = `
+	  mutation($Owner: ID!, $Name: String!, $Description: String!, $Visibility: RepositoryVisibility!) {
+	    createRepository(input: {ownerId: $Owner, name: $Name, description: $Description, visibility: $Visibility}) {
+	      clientMutationId
+	      repository {
+	        ` + repoFields + `
+	      }
+	    }
+	  }
+	`
+	vars := Vars{"Owner": owner.GetId(), "Name": name, "Description": description, "Visibility": visibility}
+	m, err := c.GraphQLMutation(graphql, vars)
+	if err != nil {
+		return nil, err
+	}
+	if m.CreateRepository == nil || m.CreateRepository.Repository == nil {
+		return nil, c.noResult("createRepository")
+	}
+	return toRepo(m.CreateRepository.Repository), nil
+}
+
+// RepoSettings holds the settings of a repository that can be changed by [Client.UpdateRepo].
+type RepoSettings struct {
+	Description string
+	Topics      []string
+	HasIssues   bool
+	HasProjects bool
+	HasWiki     bool
+}
+
+// Settings returns the current settings of the repository,
+// suitable for modifying and passing to [Client.UpdateRepo].
+func (r *Repo) Settings() *RepoSettings {
+	return &RepoSettings{
+		Description: r.Description,
+		Topics:      r.Topics,
+		HasIssues:   r.HasIssues,
+		HasProjects: r.HasProjects,
+		HasWiki:     r.HasWiki,
+	}
+}
+
+func (c *Client) UpdateRepo(r *Repo, settings *RepoSettings) (*Repo, error) {
+	graphql := `
+	  mutation($Repo: ID!, $Description: String!, $HasIssues: Boolean!, $HasProjects: Boolean!, $HasWiki: Boolean!, $Topics: [String!]!) {
+	    updateRepository(input: {repositoryId: $Repo, description: $Description, hasIssuesEnabled: $HasIssues, hasProjectsEnabled: $HasProjects, hasWikiEnabled: $HasWiki}) {
+	      clientMutationId
+	    }
+	    updateTopics(input: {repositoryId: $Repo, topicNames: $Topics}) {
+	      clientMutationId
+	      repository {
+	        ` + repoFields + `
+	      }
+	    }
+	  }
+	`
+	topics := settings.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	vars := Vars{
+		"Repo":        r.ID,
+		"Description": settings.Description,
+		"HasIssues":   settings.HasIssues,
+		"HasProjects": settings.HasProjects,
+		"HasWiki":     settings.HasWiki,
+		"Topics":      topics,
+	}
+	m, err := c.GraphQLMutation(graphql, vars)
+	if err != nil {
+		return nil, err
+	}
+	if m.UpdateTopics == nil || m.UpdateTopics.Repository == nil {
+		return nil, c.noResult("updateTopics")
+	}
+	return toRepo(m.UpdateTopics.Repository), nil
+}
+
+func (c *Client) ArchiveRepo(r *Repo) error {
+	graphql := `
+	  mutation($Repo: ID!) {
+	    archiveRepository(input: {repositoryId: $Repo}) {
+	      clientMutationId
+	    }
+	  }
+	`
+	_, err := c.GraphQLMutation(graphql, Vars{"Repo": r.ID})
+	if err == nil {
+		r.Archived = true
+	}
+	return err
+}
+
+// WatchRepo sets the authenticated user's notification subscription to the repository:
+// SUBSCRIBED to watch all activity, UNSUBSCRIBED to be notified only when
+// participating or mentioned, or IGNORED to never be notified.
+func (c *Client) WatchRepo(r *Repo, state schema.SubscriptionState) error {
+	err := c.updateSubscription(r.ID, state)
+	if err == nil {
+		r.Subscription = state
+	}
+	return err
+}
+
+// AddStar stars the repository as the authenticated user.
+func (c *Client) AddStar(r *Repo) error {
+	graphql := `
+	  mutation($Repo: ID!) {
+	    addStar(input: {starrableId: $Repo}) {
+	      starrable { __typename stargazerCount }
+	    }
+	  }
+	`
+	m, err := c.GraphQLMutation(graphql, Vars{"Repo": r.ID})
+	if err != nil {
+		return err
+	}
+	r.Starred = true
+	if m.AddStar != nil && m.AddStar.Starrable.Interface != nil {
+		r.Stars = m.AddStar.Starrable.Interface.GetStargazerCount()
+	}
+	return nil
+}
+
+// RemoveStar unstars the repository as the authenticated user.
+func (c *Client) RemoveStar(r *Repo) error {
+	graphql := `
+	  mutation($Repo: ID!) {
+	    removeStar(input: {starrableId: $Repo}) {
+	      starrable { __typename stargazerCount }
+	    }
+	  }
+	`
+	m, err := c.GraphQLMutation(graphql, Vars{"Repo": r.ID})
+	if err != nil {
+		return err
+	}
+	r.Starred = false
+	if m.RemoveStar != nil && m.RemoveStar.Starrable.Interface != nil {
+		r.Stars = m.RemoveStar.Starrable.Interface.GetStargazerCount()
+	}
+	return nil
+}
+
+// A Stargazer is a user who starred a repository.
+type Stargazer struct {
+	Login     string
+	StarredAt time.Time
+}
+
+// Stargazers returns the number of stargazers of org/repo
+// and the n users who starred it most recently, newest first.
+func (c *Client) Stargazers(org, repo string, n int) (int, []*Stargazer, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $N: Int!) {
+	    repository(owner: $Org, name: $Repo) {
+	      stargazers(first: $N, orderBy: {field: STARRED_AT, direction: DESC}) {
+	        totalCount
+	        edges {
+	          starredAt
+	          node { login }
+	        }
+	      }
+	    }
+	  }
+	`
+	q, err := c.GraphQLQuery(graphql, Vars{"Org": org, "Repo": repo, "N": n})
+	if err != nil {
+		return 0, nil, err
+	}
+	if q.Repository == nil || q.Repository.Stargazers == nil {
+		return 0, nil, fmt.Errorf("%s/%s: no stargazers in reply", org, repo)
+	}
+	var list []*Stargazer
+	for _, e := range q.Repository.Stargazers.Edges {
+		s := &Stargazer{StarredAt: toTime(e.StarredAt)}
+		if e.Node != nil {
+			s.Login = e.Node.Login
+		}
+		list = append(list, s)
+	}
+	return q.Repository.Stargazers.TotalCount, list, nil
+}
+
+// Forks returns the direct forks of org/repo.
+func (c *Client) Forks(org, repo string) ([]*Repo, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      forks(first: $PageSize, after: $Cursor) {
+	        pageInfo {
+	          hasNextPage
+	          endCursor
+	        }
+	        totalCount
+	        nodes {
+	          ` + repoFields + `
+	        }
+	      }
+	    }
+	  }
+	`
+
+	vars := Vars{"Org": org, "Repo": repo}
+	return collect(c, graphql, vars, toRepo,
+		func(q *schema.Query) pager[*schema.Repository] {
+			if q.Repository == nil || q.Repository.Forks == nil {
+				return nil
+			}
+			return q.Repository.Forks
+		},
+	)
+}
+
+// CreateFork forks the repository into the organization org,
+// or into the authenticated user's account if org is the empty string.
+// GitHub creates the fork asynchronously, so its content
+// may not be available immediately.
+func (c *Client) CreateFork(r *Repo, org string) (*Repo, error) {
+	body := map[string]any{}
+	if org != "" {
+		body["organization"] = org
+	}
+	var j struct {
+		NodeID      string   `json:"node_id"`
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		HTMLURL     string   `json:"html_url"`
+		Visibility  string   `json:"visibility"`
+		Archived    bool     `json:"archived"`
+		Fork        bool     `json:"fork"`
+		HasIssues   bool     `json:"has_issues"`
+		HasProjects bool     `json:"has_projects"`
+		HasWiki     bool     `json:"has_wiki"`
+		Branch      string   `json:"default_branch"`
+		Topics      []string `json:"topics"`
+		Owner       struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+		Parent struct {
+			FullName string `json:"full_name"`
+		} `json:"parent"`
+	}
+	if err := c.rest("POST", "/repos/"+r.Owner+"/"+r.Repo+"/forks", body, &j); err != nil {
+		return nil, err
+	}
+	if c.isDryRunREST("POST") {
+		return nil, ErrDryRun
+	}
+	return &Repo{
+		Owner:         j.Owner.Login,
+		Repo:          j.Name,
+		ID:            j.NodeID,
+		Description:   j.Description,
+		URL:           j.HTMLURL,
+		Visibility:    schema.RepositoryVisibility(strings.ToUpper(j.Visibility)),
+		Archived:      j.Archived,
+		Fork:          j.Fork,
+		Parent:        j.Parent.FullName,
+		DefaultBranch: j.Branch,
+		Topics:        j.Topics,
+		HasIssues:     j.HasIssues,
+		HasProjects:   j.HasProjects,
+		HasWiki:       j.HasWiki,
+	}, nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Some parts of the GitHub API, such as GitHub Actions,
+// are only available using the REST API, not GraphQL.
+// The code in this file makes REST requests using the Client's credentials.
+
+// REST makes a REST API request using c's credentials and settings,
+// including its retry policy for rate-limited requests.
+// It is meant for the parts of the GitHub API that have no GraphQL equivalent,
+// such as repository traffic or reactions on old comments.
+//
+// The path is relative to the API root, as in "/repos/golang/go/traffic/views",
+// or else a full URL. If body is non-nil, it is sent as JSON.
+// If reply is non-nil, the JSON response is decoded into reply.
+//
+// REST fetches a single page of results;
+// to fetch all pages of a list, use [Client.RESTList].
+func (c *Client) REST(method, path string, body, reply any) error {
+	return c.rest(method, path, body, reply)
+}
+
+// RESTList fetches all pages of a REST API list result
+// (subject to the limits set by [Client.WithPageSize] and [Client.WithLimit])
+// and decodes the combined results into list, which must be a pointer to a slice.
+// If field is empty, each page is expected to be a JSON array of results.
+// Otherwise each page is expected to be a JSON object with
+// the results in the named field, as in {"total_count": 2, "artifacts": [...]}.
+func (c *Client) RESTList(path, field string, list any) error {
+	items, err := restCollect[json.RawMessage](c, path, field)
+	if items == nil {
+		items = []json.RawMessage{}
+	}
+	js, jerr := json.Marshal(items)
+	if jerr == nil {
+		jerr = json.Unmarshal(js, list)
+	}
+	if err == nil && jerr != nil {
+		err = fmt.Errorf("parsing reply: %v", jerr)
+	}
+	return err
+}
+
+// restBody makes a REST API request and returns the response body.
+// The path is relative to the API root, as in "/repos/golang/go/actions/runs",
+// or else a full URL, as found in Link headers and some API responses.
+// The accept string is the media type to request;
+// if empty, restBody requests "application/vnd.github+json".
+// If body is non-nil, it is sent as JSON.
+func (c *Client) restBody(method, path, accept string, body any) (*http.Response, []byte, error) {
+	var js []byte
+	if body != nil {
+		var err error
+		js, err = json.Marshal(body)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if resp := c.dryRunREST(method, path, body); resp != nil {
+		return resp, nil, nil
+	}
+
+	url := path
+	if strings.HasPrefix(path, "/") {
+		url = c.restURL() + path
+	}
+	for attempt := 1; ; attempt++ {
+		if err := c.waitLimiter(); err != nil {
+			return nil, nil, err
+		}
+		var rbody io.Reader
+		if js != nil {
+			rbody = bytes.NewReader(js)
+		}
+		ctx, cancel := c.requestContext()
+		req, err := http.NewRequestWithContext(ctx, method, url, rbody)
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		token, err := c.authToken()
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if accept == "" {
+			accept = "application/vnd.github+json"
+		}
+		req.Header.Set("Accept", accept)
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+		if js != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		done := c.startRequest(&RequestInfo{
+			Context: c.context(),
+			Method:  method,
+			URL:     url,
+			Attempt: attempt,
+		})
+		resp, err := c.HTTPClient().Do(req)
+		if err != nil {
+			cancel()
+			done(nil, 0, err)
+			return nil, nil, err
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		cancel()
+		if err != nil {
+			err = fmt.Errorf("reading body: %v", err)
+			done(resp, 0, err)
+			return resp, nil, err
+		}
+		if resp.StatusCode/100 != 2 {
+			err := fmt.Errorf("%s %s: %s\n%s", method, path, resp.Status, data)
+			done(resp, 0, err)
+			if rl := checkRateLimit(resp, err.Error()); rl != nil {
+				c.pauseLimiter(rl.Wait)
+				if err := c.retryWait(attempt, rl, rl.Wait); err != nil {
+					return resp, data, err
+				}
+				continue
+			}
+			return resp, data, err
+		}
+		done(resp, 0, nil)
+		c.auditREST(method, path, body)
+		return resp, data, nil
+	}
+}
+
+// rest makes a REST API request and decodes the JSON response into reply.
+// If reply is nil, the response is discarded.
+func (c *Client) rest(method, path string, body, reply any) error {
+	_, data, err := c.restBody(method, path, "", body)
+	if err != nil {
+		return err
+	}
+	if reply == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, reply); err != nil {
+		return fmt.Errorf("parsing reply: %v", err)
+	}
+	return nil
+}
+
+// restCollect fetches all pages of a REST API list result.
+// If field is empty, each page is expected to be a JSON array of results.
+// Otherwise each page is expected to be a JSON object with
+// the results in the named field, as in {"total_count": 2, "artifacts": [...]}.
+func restCollect[T any](c *Client, path, field string) ([]T, error) {
+	return restCollectAccept[T](c, path, "", field)
+}
+
+// restCollectAccept is like restCollect but requests the given media type.
+func restCollectAccept[T any](c *Client, path, accept, field string) ([]T, error) {
+	var list []T
+	url := path
+	if !strings.Contains(url, "per_page=") {
+		if strings.Contains(url, "?") {
+			url += "&"
+		} else {
+			url += "?"
+		}
+		url += fmt.Sprintf("per_page=%d", c.pageLimit(0))
+	}
+	for url != "" {
+		resp, data, err := c.restBody("GET", url, accept, nil)
+		if err != nil {
+			return list, err
+		}
+		if field != "" {
+			var obj map[string]json.RawMessage
+			if err := json.Unmarshal(data, &obj); err != nil {
+				return list, fmt.Errorf("parsing reply: %v", err)
+			}
+			data = obj[field]
+		}
+		var page []T
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &page); err != nil {
+				return list, fmt.Errorf("parsing reply: %v", err)
+			}
+		}
+		list = append(list, page...)
+		if c.limit > 0 && len(list) >= c.limit {
+			list = list[:c.limit]
+			break
+		}
+		url = findNext(resp.Header.Get("Link"))
+	}
+	return list, nil
+}
+
+// findNext returns the URL of the next page in a Link header,
+// or the empty string if there is no next page.
+func findNext(link string) string {
+	for _, elem := range strings.Split(link, ",") {
+		elem = strings.TrimSpace(elem)
+		url, params, ok := strings.Cut(elem, ";")
+		if !ok || !strings.HasPrefix(url, "<") || !strings.HasSuffix(url, ">") {
+			continue
+		}
+		for _, p := range strings.Split(params, ";") {
+			if strings.TrimSpace(p) == `rel="next"` {
+				return url[1 : len(url)-1]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"log"
+	"math/rand"
+	"time"
+)
+
+// A RetryPolicy controls how a Client retries requests
+// that fail because of GitHub rate limits.
+//
+// The default policy, used when a Client has no policy set,
+// retries indefinitely, waiting as long as GitHub asks
+// (using the Retry-After and X-RateLimit-Reset response headers),
+// and logs each wait using the log package.
+// Batch jobs generally want that behavior;
+// interactive tools may prefer to fail fast:
+//
+//	c = c.WithRetryPolicy(&github.RetryPolicy{MaxAttempts: 1})
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts for a single request,
+	// including the first. Zero means no limit.
+	MaxAttempts int
+
+	// Backoff returns how long to wait before the given retry
+	// (1 for the first retry, 2 for the second, and so on),
+	// given the wait suggested by GitHub's response.
+	// If Backoff is nil, the Client waits the suggested time.
+	Backoff func(retry int, suggested time.Duration) time.Duration
+
+	// Jitter is the fraction, between 0 and 1, by which to randomly
+	// lengthen or shorten each wait, to avoid many clients retrying in lockstep.
+	Jitter float64
+
+	// OnWait, if non-nil, is called before each wait
+	// with the error being retried and the length of the wait.
+	// If OnWait is nil, the Client logs the wait using the log package.
+	OnWait func(err error, wait time.Duration)
+}
+
+// WithRetryPolicy returns a shallow copy of c that uses the retry policy p.
+// If p is nil, the copy uses the default policy (see [RetryPolicy]).
+func (c *Client) WithRetryPolicy(p *RetryPolicy) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.retry = p
+	return c2
+}
+
+// retryWait waits before retrying a request that failed with err,
+// whose response suggested waiting for the given duration.
+// Err is usually a *RateLimitError.
+// The attempt count is the number of attempts made so far.
+// If the retry policy allows another attempt, retryWait waits and returns nil.
+// Otherwise it returns err, or the context's error if the context
+// was canceled during the wait.
+func (c *Client) retryWait(attempt int, err error, suggested time.Duration) error {
+	p := c.retry
+	if p == nil {
+		p = new(RetryPolicy)
+	}
+	if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+		return err
+	}
+	wait := suggested
+	if p.Backoff != nil {
+		wait = p.Backoff(attempt, suggested)
+	}
+	if p.Jitter > 0 {
+		wait += time.Duration((2*rand.Float64() - 1) * p.Jitter * float64(wait))
+	}
+	if p.OnWait != nil {
+		p.OnWait(err, wait)
+	} else {
+		log.Printf("github: %v (retrying in %v)", err, wait)
+	}
+	return c.sleep(wait)
+}
@@ -0,0 +1,448 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"strings"
+)
+
+// Repository rulesets are newer than the schema package,
+// so the code in this file decodes GraphQL replies into
+// its own data structures instead of using schema.Query.
+
+const rulesetFields = `
+  id
+  databaseId
+  name
+  target
+  enforcement
+  source {
+    __typename
+    ... on Repository { id nameWithOwner }
+    ... on Organization { id login }
+  }
+  conditions {
+    refName { include exclude }
+    repositoryName { include exclude protected }
+  }
+  bypassActors(first: 100) {
+    nodes {
+      actor {
+        __typename
+        ... on App { id name }
+        ... on Team { id name }
+      }
+      bypassMode
+      deployKey
+      organizationAdmin
+      repositoryRoleDatabaseId
+      repositoryRoleName
+    }
+  }
+  rules(first: 100) {
+    nodes {
+      type
+      parameters {
+        __typename
+        ... on RequiredStatusChecksParameters {
+          requiredStatusChecks { context integrationId }
+          strictRequiredStatusChecksPolicy
+        }
+        ... on PullRequestParameters {
+          dismissStaleReviewsOnPush
+          requireCodeOwnerReview
+          requireLastPushApproval
+          requiredApprovingReviewCount
+          requiredReviewThreadResolution
+        }
+        ... on RequiredDeploymentsParameters { requiredDeploymentEnvironments }
+        ... on UpdateParameters { updateAllowsFetchAndMerge }
+        ... on FilePathRestrictionParameters { restrictedFilePaths }
+        ... on FileExtensionRestrictionParameters { restrictedFileExtensions }
+        ... on MaxFilePathLengthParameters { maxFilePathLength }
+        ... on MaxFileSizeParameters { maxFileSize }
+        ... on CommitMessagePatternParameters { name negate operator pattern }
+        ... on CommitAuthorEmailPatternParameters { name negate operator pattern }
+        ... on CommitterEmailPatternParameters { name negate operator pattern }
+        ... on BranchNamePatternParameters { name negate operator pattern }
+        ... on TagNamePatternParameters { name negate operator pattern }
+      }
+    }
+  }
+`
+
+// A Ruleset is a repository or organization ruleset.
+type Ruleset struct {
+	ID           string
+	DatabaseID   int
+	Name         string
+	Target       string // "BRANCH", "TAG", or "PUSH"
+	Enforcement  string // "ACTIVE", "DISABLED", or "EVALUATE"
+	Source       string // "owner/repo" for repository rulesets, "org" for organization rulesets
+	Conditions   RulesetConditions
+	BypassActors []*RulesetBypassActor
+	Rules        []*RulesetRule
+}
+
+// RulesetConditions are the conditions determining
+// which refs and repositories a ruleset applies to.
+type RulesetConditions struct {
+	RefInclude  []string // ref name patterns, like "~DEFAULT_BRANCH" or "refs/heads/release-*"
+	RefExclude  []string
+	RepoInclude []string // repository name patterns (organization rulesets only)
+	RepoExclude []string
+}
+
+// A RulesetBypassActor is an actor allowed to bypass a ruleset.
+type RulesetBypassActor struct {
+	Kind                     string // "App", "Team", "OrganizationAdmin", "RepositoryRole", or "DeployKey"
+	ActorID                  string // for "App" and "Team"
+	ActorName                string // for "App" and "Team"
+	RepositoryRoleDatabaseID int    // for "RepositoryRole"
+	RepositoryRoleName       string // for "RepositoryRole"
+	BypassMode               string // "ALWAYS" or "PULL_REQUEST"
+}
+
+// A RulesetRule is a single rule in a ruleset.
+// Type is the GraphQL RepositoryRuleType, such as "REQUIRED_STATUS_CHECKS",
+// "PULL_REQUEST", "FILE_PATH_RESTRICTION", or "DELETION".
+// Parameters holds the rule's parameters, if any, using the GraphQL field names.
+// For example, a REQUIRED_STATUS_CHECKS rule has parameters like:
+//
+//	map[string]any{
+//		"requiredStatusChecks": []any{
+//			map[string]any{"context": "ci/test"},
+//		},
+//		"strictRequiredStatusChecksPolicy": true,
+//	}
+type RulesetRule struct {
+	Type       string
+	Parameters map[string]any
+}
+
+type rulesetSchema struct {
+	ID          string `json:"id"`
+	DatabaseID  int    `json:"databaseId"`
+	Name        string `json:"name"`
+	Target      string `json:"target"`
+	Enforcement string `json:"enforcement"`
+	Source      struct {
+		ID            string `json:"id"`
+		NameWithOwner string `json:"nameWithOwner"`
+		Login         string `json:"login"`
+	} `json:"source"`
+	Conditions struct {
+		RefName *struct {
+			Include []string `json:"include"`
+			Exclude []string `json:"exclude"`
+		} `json:"refName"`
+		RepositoryName *struct {
+			Include []string `json:"include"`
+			Exclude []string `json:"exclude"`
+		} `json:"repositoryName"`
+	} `json:"conditions"`
+	BypassActors struct {
+		Nodes []struct {
+			Actor *struct {
+				Typename string `json:"__typename"`
+				ID       string `json:"id"`
+				Name     string `json:"name"`
+			} `json:"actor"`
+			BypassMode               string `json:"bypassMode"`
+			DeployKey                bool   `json:"deployKey"`
+			OrganizationAdmin        bool   `json:"organizationAdmin"`
+			RepositoryRoleDatabaseID int    `json:"repositoryRoleDatabaseId"`
+			RepositoryRoleName       string `json:"repositoryRoleName"`
+		} `json:"nodes"`
+	} `json:"bypassActors"`
+	Rules struct {
+		Nodes []struct {
+			Type       string         `json:"type"`
+			Parameters map[string]any `json:"parameters"`
+		} `json:"nodes"`
+	} `json:"rules"`
+}
+
+func toRuleset(s *rulesetSchema) *Ruleset {
+	r := &Ruleset{
+		ID:          s.ID,
+		DatabaseID:  s.DatabaseID,
+		Name:        s.Name,
+		Target:      s.Target,
+		Enforcement: s.Enforcement,
+		Source:      s.Source.NameWithOwner,
+	}
+	if r.Source == "" {
+		r.Source = s.Source.Login
+	}
+	if c := s.Conditions.RefName; c != nil {
+		r.Conditions.RefInclude = c.Include
+		r.Conditions.RefExclude = c.Exclude
+	}
+	if c := s.Conditions.RepositoryName; c != nil {
+		r.Conditions.RepoInclude = c.Include
+		r.Conditions.RepoExclude = c.Exclude
+	}
+	for _, sa := range s.BypassActors.Nodes {
+		a := &RulesetBypassActor{BypassMode: sa.BypassMode}
+		switch {
+		case sa.Actor != nil:
+			a.Kind = sa.Actor.Typename
+			a.ActorID = sa.Actor.ID
+			a.ActorName = sa.Actor.Name
+		case sa.OrganizationAdmin:
+			a.Kind = "OrganizationAdmin"
+		case sa.DeployKey:
+			a.Kind = "DeployKey"
+		case sa.RepositoryRoleName != "" || sa.RepositoryRoleDatabaseID != 0:
+			a.Kind = "RepositoryRole"
+			a.RepositoryRoleDatabaseID = sa.RepositoryRoleDatabaseID
+			a.RepositoryRoleName = sa.RepositoryRoleName
+		}
+		r.BypassActors = append(r.BypassActors, a)
+	}
+	for _, sr := range s.Rules.Nodes {
+		params := sr.Parameters
+		delete(params, "__typename")
+		if len(params) == 0 {
+			params = nil
+		}
+		r.Rules = append(r.Rules, &RulesetRule{Type: sr.Type, Parameters: params})
+	}
+	return r
+}
+
+// input returns the GraphQL input object fields for creating or updating r.
+func (r *Ruleset) input() map[string]any {
+	conditions := map[string]any{
+		"refName": map[string]any{
+			"include": nonNil(r.Conditions.RefInclude),
+			"exclude": nonNil(r.Conditions.RefExclude),
+		},
+	}
+	if r.Conditions.RepoInclude != nil || r.Conditions.RepoExclude != nil {
+		conditions["repositoryName"] = map[string]any{
+			"include": nonNil(r.Conditions.RepoInclude),
+			"exclude": nonNil(r.Conditions.RepoExclude),
+		}
+	}
+
+	actors := []map[string]any{}
+	for _, a := range r.BypassActors {
+		actor := map[string]any{"bypassMode": a.BypassMode}
+		switch a.Kind {
+		case "OrganizationAdmin":
+			actor["organizationAdmin"] = true
+		case "DeployKey":
+			actor["deployKey"] = true
+		case "RepositoryRole":
+			actor["repositoryRoleDatabaseId"] = a.RepositoryRoleDatabaseID
+		default:
+			actor["actorId"] = a.ActorID
+		}
+		actors = append(actors, actor)
+	}
+
+	rules := []map[string]any{}
+	for _, rule := range r.Rules {
+		in := map[string]any{"type": rule.Type}
+		if rule.Parameters != nil {
+			in["parameters"] = map[string]any{ruleParameterName(rule.Type): rule.Parameters}
+		}
+		rules = append(rules, in)
+	}
+
+	return map[string]any{
+		"name":         r.Name,
+		"target":       r.Target,
+		"enforcement":  r.Enforcement,
+		"conditions":   conditions,
+		"bypassActors": actors,
+		"rules":        rules,
+	}
+}
+
+// ruleParameterName returns the name of the RuleParametersInput field
+// holding the parameters for the rule type typ.
+// For example, "REQUIRED_STATUS_CHECKS" becomes "requiredStatusChecks".
+func ruleParameterName(typ string) string {
+	var b strings.Builder
+	for i, word := range strings.Split(strings.ToLower(typ), "_") {
+		if i > 0 && word != "" {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
+
+func nonNil(x []string) []string {
+	if x == nil {
+		x = []string{}
+	}
+	return x
+}
+
+type rulesetReply struct {
+	Repository *struct {
+		Rulesets *connection[*rulesetSchema]
+	}
+	Organization *struct {
+		ID       string
+		Rulesets *connection[*rulesetSchema]
+	}
+}
+
+// RepoRulesets returns the rulesets that apply to the repository org/repo,
+// including rulesets defined by the organization.
+func (c *Client) RepoRulesets(org, repo string) ([]*Ruleset, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      rulesets(first: $PageSize, includeParents: true, after: $Cursor) {
+	        pageInfo {
+	          hasNextPage
+	          endCursor
+	        }
+	        totalCount
+	        nodes {
+	          ` + rulesetFields + `
+	        }
+	      }
+	    }
+	  }
+	`
+
+	vars := Vars{"Org": org, "Repo": repo}
+	return collectReply(c, graphql, vars, toRuleset,
+		func(q *rulesetReply) pager[*rulesetSchema] {
+			if q.Repository == nil || q.Repository.Rulesets == nil {
+				return nil
+			}
+			return q.Repository.Rulesets
+		},
+	)
+}
+
+// OrgRulesets returns the rulesets defined by the organization org.
+func (c *Client) OrgRulesets(org string) ([]*Ruleset, error) {
+	graphql := `
+	  query($Org: String!, $Cursor: String, $PageSize: Int = 100) {
+	    organization(login: $Org) {
+	      rulesets(first: $PageSize, after: $Cursor) {
+	        pageInfo {
+	          hasNextPage
+	          endCursor
+	        }
+	        totalCount
+	        nodes {
+	          ` + rulesetFields + `
+	        }
+	      }
+	    }
+	  }
+	`
+
+	vars := Vars{"Org": org}
+	return collectReply(c, graphql, vars, toRuleset,
+		func(q *rulesetReply) pager[*rulesetSchema] {
+			if q.Organization == nil || q.Organization.Rulesets == nil {
+				return nil
+			}
+			return q.Organization.Rulesets
+		},
+	)
+}
+
+// CreateRepoRuleset adds the ruleset r to the repository.
+// The ID, DatabaseID, and Source fields of r are ignored.
+func (c *Client) CreateRepoRuleset(repo *Repo, r *Ruleset) (*Ruleset, error) {
+	return c.createRuleset(repo.ID, r)
+}
+
+// CreateOrgRuleset adds the ruleset r to the organization org.
+// The ID, DatabaseID, and Source fields of r are ignored.
+func (c *Client) CreateOrgRuleset(org string, r *Ruleset) (*Ruleset, error) {
+	graphql := `
+	  query($Org: String!) {
+	    organization(login: $Org) {
+	      id
+	    }
+	  }
+	`
+	var reply rulesetReply
+	if err := c.graphQL(graphql, Vars{"Org": org}, &reply); err != nil {
+		return nil, err
+	}
+	return c.createRuleset(reply.Organization.ID, r)
+}
+
+func (c *Client) createRuleset(sourceID string, r *Ruleset) (*Ruleset, error) {
+	graphql := `
+	  mutation($Input: CreateRepositoryRulesetInput!) {
+	    createRepositoryRuleset(input: $Input) {
+	      clientMutationId
+	      ruleset {
+	        ` + rulesetFields + `
+	      }
+	    }
+	  }
+	`
+	input := r.input()
+	input["sourceId"] = sourceID
+	var reply struct {
+		CreateRepositoryRuleset struct {
+			Ruleset *rulesetSchema
+		}
+	}
+	if err := c.graphQL(graphql, Vars{"Input": input}, &reply); err != nil {
+		return nil, err
+	}
+	if reply.CreateRepositoryRuleset.Ruleset == nil {
+		return nil, c.noResult("createRepositoryRuleset")
+	}
+	return toRuleset(reply.CreateRepositoryRuleset.Ruleset), nil
+}
+
+// UpdateRuleset replaces the settings of the existing ruleset
+// identified by r.ID with the settings in r.
+func (c *Client) UpdateRuleset(r *Ruleset) (*Ruleset, error) {
+	graphql := `
+	  mutation($Input: UpdateRepositoryRulesetInput!) {
+	    updateRepositoryRuleset(input: $Input) {
+	      clientMutationId
+	      ruleset {
+	        ` + rulesetFields + `
+	      }
+	    }
+	  }
+	`
+	input := r.input()
+	input["repositoryRulesetId"] = r.ID
+	var reply struct {
+		UpdateRepositoryRuleset struct {
+			Ruleset *rulesetSchema
+		}
+	}
+	if err := c.graphQL(graphql, Vars{"Input": input}, &reply); err != nil {
+		return nil, err
+	}
+	if reply.UpdateRepositoryRuleset.Ruleset == nil {
+		return nil, c.noResult("updateRepositoryRuleset")
+	}
+	return toRuleset(reply.UpdateRepositoryRuleset.Ruleset), nil
+}
+
+func (c *Client) DeleteRuleset(r *Ruleset) error {
+	graphql := `
+	  mutation($Ruleset: ID!) {
+	    deleteRepositoryRuleset(input: {repositoryRulesetId: $Ruleset}) {
+	      clientMutationId
+	    }
+	  }
+	`
+	_, err := c.GraphQLMutation(graphql, Vars{"Ruleset": r.ID})
+	return err
+}
@@ -6,6 +6,8 @@
 
 // go run generate.go downloads the latest GraphQL schema from GitHub
 // and generates corresponding Go data structures in schema.go.
+// To download the schema from a GitHub Enterprise Server instance instead,
+// set $GITHUB_HOST to its host name.
 package main
 
 import (
@@ -28,7 +30,11 @@ func main() {
 	log.SetFlags(log.Lshortfile)
 	data, err := os.ReadFile("schema.js")
 	if err != nil {
-		c, err := graphql.Dial()
+		host := os.Getenv("GITHUB_HOST")
+		if host == "" {
+			host = "api.github.com"
+		}
+		c, err := graphql.DialHost(host)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -147,6 +147,7 @@ func (x *Actor) UnmarshalJSON(js []byte) error {
 	default:
 		return fmt.Errorf("unexpected type %q for Actor", info.Typename)
 	case "":
+		// JSON null: no value.
 		x.Interface = nil
 		return nil
 	case "Bot":
@@ -1132,6 +1133,10 @@ func (x *Assignable) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Assignable", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Issue":
 		x.Interface = new(Issue)
 	case "PullRequest":
@@ -1206,6 +1211,10 @@ func (x *Assignee) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Assignee", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Bot":
 		x.Interface = new(Bot)
 	case "Mannequin":
@@ -1375,6 +1384,10 @@ func (x *AuditEntry) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for AuditEntry", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "MembersCanDeleteReposClearAuditEntry":
 		x.Interface = new(MembersCanDeleteReposClearAuditEntry)
 	case "MembersCanDeleteReposDisableAuditEntry":
@@ -1529,6 +1542,10 @@ func (x *AuditEntryActor) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for AuditEntryActor", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Bot":
 		x.Interface = new(Bot)
 	case "Organization":
@@ -1989,6 +2006,10 @@ func (x *BranchActorAllowanceActor) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for BranchActorAllowanceActor", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "App":
 		x.Interface = new(App)
 	case "Team":
@@ -3385,6 +3406,10 @@ func (x *Closable) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Closable", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Issue":
 		x.Interface = new(Issue)
 	case "Milestone":
@@ -3524,6 +3549,10 @@ func (x *Closer) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Closer", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Commit":
 		x.Interface = new(Commit)
 	case "PullRequest":
@@ -3637,6 +3666,10 @@ func (x *Comment) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Comment", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "CommitComment":
 		x.Interface = new(CommitComment)
 	case "Discussion":
@@ -4438,6 +4471,10 @@ func (x *Contribution) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Contribution", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "CreatedCommitContribution":
 		x.Interface = new(CreatedCommitContribution)
 	case "CreatedIssueContribution":
@@ -6176,6 +6213,10 @@ func (x *CreatedIssueOrRestrictedContribution) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for CreatedIssueOrRestrictedContribution", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "CreatedIssueContribution":
 		x.Interface = new(CreatedIssueContribution)
 	case "RestrictedContribution":
@@ -6284,6 +6325,10 @@ func (x *CreatedPullRequestOrRestrictedContribution) UnmarshalJSON(js []byte) er
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for CreatedPullRequestOrRestrictedContribution", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "CreatedPullRequestContribution":
 		x.Interface = new(CreatedPullRequestContribution)
 	case "RestrictedContribution":
@@ -6472,6 +6517,10 @@ func (x *CreatedRepositoryOrRestrictedContribution) UnmarshalJSON(js []byte) err
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for CreatedRepositoryOrRestrictedContribution", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "CreatedRepositoryContribution":
 		x.Interface = new(CreatedRepositoryContribution)
 	case "RestrictedContribution":
@@ -6627,6 +6676,10 @@ func (x *Deletable) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Deletable", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "CommitComment":
 		x.Interface = new(CommitComment)
 	case "Discussion":
@@ -7709,6 +7762,10 @@ func (x *DeploymentReviewer) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for DeploymentReviewer", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Team":
 		x.Interface = new(Team)
 	case "User":
@@ -9125,6 +9182,10 @@ func (x *EnterpriseAuditEntryData) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for EnterpriseAuditEntryData", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "MembersCanDeleteReposClearAuditEntry":
 		x.Interface = new(MembersCanDeleteReposClearAuditEntry)
 	case "MembersCanDeleteReposDisableAuditEntry":
@@ -9313,6 +9374,10 @@ func (x *EnterpriseMember) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for EnterpriseMember", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "EnterpriseUserAccount":
 		x.Interface = new(EnterpriseUserAccount)
 	case "User":
@@ -11556,6 +11621,10 @@ func (x *GitObject) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for GitObject", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Blob":
 		x.Interface = new(Blob)
 	case "Commit":
@@ -11615,6 +11684,10 @@ func (x *GitSignature) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for GitSignature", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "GpgSignature":
 		x.Interface = new(GpgSignature)
 	case "SmimeSignature":
@@ -11924,6 +11997,10 @@ func (x *HovercardContext) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for HovercardContext", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "GenericHovercardContext":
 		x.Interface = new(GenericHovercardContext)
 	case "OrganizationTeamsHovercardContext":
@@ -12136,6 +12213,10 @@ func (x *IpAllowListOwner) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for IpAllowListOwner", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "App":
 		x.Interface = new(App)
 	case "Enterprise":
@@ -12838,6 +12919,10 @@ func (x *IssueOrPullRequest) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for IssueOrPullRequest", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Issue":
 		x.Interface = new(Issue)
 	case "PullRequest":
@@ -12998,6 +13083,10 @@ func (x *IssueTimelineItem) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for IssueTimelineItem", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "AssignedEvent":
 		x.Interface = new(AssignedEvent)
 	case "ClosedEvent":
@@ -13142,6 +13231,10 @@ func (x *IssueTimelineItems) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for IssueTimelineItems", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "AddedToProjectEvent":
 		x.Interface = new(AddedToProjectEvent)
 	case "AssignedEvent":
@@ -13538,6 +13631,10 @@ func (x *Labelable) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Labelable", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Discussion":
 		x.Interface = new(Discussion)
 	case "Issue":
@@ -13846,6 +13943,10 @@ func (x *Lockable) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Lockable", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Discussion":
 		x.Interface = new(Discussion)
 	case "Issue":
@@ -14399,6 +14500,10 @@ func (x *MemberStatusable) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for MemberStatusable", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Organization":
 		x.Interface = new(Organization)
 	case "Team":
@@ -14919,6 +15024,10 @@ func (x *Migration) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Migration", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "RepositoryMigration":
 		x.Interface = new(RepositoryMigration)
 	}
@@ -15144,6 +15253,10 @@ func (x *MilestoneItem) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for MilestoneItem", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Issue":
 		x.Interface = new(Issue)
 	case "PullRequest":
@@ -15254,6 +15367,10 @@ func (x *Minimizable) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Minimizable", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "CommitComment":
 		x.Interface = new(CommitComment)
 	case "DiscussionComment":
@@ -17447,6 +17564,10 @@ func (x *Node) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Node", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "AddedToProjectEvent":
 		x.Interface = new(AddedToProjectEvent)
 	case "App":
@@ -17994,6 +18115,10 @@ func (x *OauthApplicationAuditEntryData) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for OauthApplicationAuditEntryData", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "OauthApplicationCreateAuditEntry":
 		x.Interface = new(OauthApplicationCreateAuditEntry)
 	case "OrgOauthAppAccessApprovedAuditEntry":
@@ -20258,6 +20383,10 @@ func (x *OrgRestoreMemberAuditEntryMembership) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for OrgRestoreMemberAuditEntryMembership", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "OrgRestoreMemberMembershipOrganizationAuditEntryData":
 		x.Interface = new(OrgRestoreMemberMembershipOrganizationAuditEntryData)
 	case "OrgRestoreMemberMembershipRepositoryAuditEntryData":
@@ -21650,6 +21779,10 @@ func (x *OrganizationAuditEntry) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for OrganizationAuditEntry", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "MembersCanDeleteReposClearAuditEntry":
 		x.Interface = new(MembersCanDeleteReposClearAuditEntry)
 	case "MembersCanDeleteReposDisableAuditEntry":
@@ -21940,6 +22073,10 @@ func (x *OrganizationAuditEntryData) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for OrganizationAuditEntryData", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "MembersCanDeleteReposClearAuditEntry":
 		x.Interface = new(MembersCanDeleteReposClearAuditEntry)
 	case "MembersCanDeleteReposDisableAuditEntry":
@@ -22383,6 +22520,10 @@ func (x *OrganizationOrUser) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for OrganizationOrUser", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Organization":
 		x.Interface = new(Organization)
 	case "User":
@@ -22703,6 +22844,10 @@ func (x *PackageOwner) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for PackageOwner", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Organization":
 		x.Interface = new(Organization)
 	case "Repository":
@@ -22946,6 +23091,10 @@ func (x *PermissionGranter) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for PermissionGranter", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Organization":
 		x.Interface = new(Organization)
 	case "Repository":
@@ -23029,6 +23178,10 @@ func (x *PinnableItem) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for PinnableItem", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Gist":
 		x.Interface = new(Gist)
 	case "Repository":
@@ -23610,6 +23763,10 @@ func (x *ProfileOwner) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ProfileOwner", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Organization":
 		x.Interface = new(Organization)
 	case "User":
@@ -23844,6 +24001,10 @@ func (x *ProjectCardItem) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ProjectCardItem", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Issue":
 		x.Interface = new(Issue)
 	case "PullRequest":
@@ -24268,6 +24429,10 @@ func (x *ProjectNextFieldCommon) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ProjectNextFieldCommon", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "ProjectNextField":
 		x.Interface = new(ProjectNextField)
 	}
@@ -24476,6 +24641,10 @@ func (x *ProjectNextItemContent) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ProjectNextItemContent", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "DraftIssue":
 		x.Interface = new(DraftIssue)
 	case "Issue":
@@ -24636,6 +24805,10 @@ func (x *ProjectNextOwner) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ProjectNextOwner", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Issue":
 		x.Interface = new(Issue)
 	case "Organization":
@@ -24713,6 +24886,10 @@ func (x *ProjectOwner) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ProjectOwner", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Organization":
 		x.Interface = new(Organization)
 	case "Repository":
@@ -25003,6 +25180,10 @@ func (x *ProjectV2FieldCommon) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ProjectV2FieldCommon", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "ProjectV2Field":
 		x.Interface = new(ProjectV2Field)
 	case "ProjectV2IterationField":
@@ -25047,6 +25228,10 @@ func (x *ProjectV2FieldConfiguration) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ProjectV2FieldConfiguration", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "ProjectV2Field":
 		x.Interface = new(ProjectV2Field)
 	case "ProjectV2IterationField":
@@ -25332,6 +25517,10 @@ func (x *ProjectV2ItemContent) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ProjectV2ItemContent", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "DraftIssue":
 		x.Interface = new(DraftIssue)
 	case "Issue":
@@ -25709,6 +25898,10 @@ func (x *ProjectV2ItemFieldValue) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ProjectV2ItemFieldValue", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "ProjectV2ItemFieldDateValue":
 		x.Interface = new(ProjectV2ItemFieldDateValue)
 	case "ProjectV2ItemFieldIterationValue":
@@ -25780,6 +25973,10 @@ func (x *ProjectV2ItemFieldValueCommon) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ProjectV2ItemFieldValueCommon", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "ProjectV2ItemFieldDateValue":
 		x.Interface = new(ProjectV2ItemFieldDateValue)
 	case "ProjectV2ItemFieldIterationValue":
@@ -26032,6 +26229,10 @@ func (x *ProjectV2Owner) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ProjectV2Owner", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Issue":
 		x.Interface = new(Issue)
 	case "Organization":
@@ -26079,6 +26280,10 @@ func (x *ProjectV2Recent) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ProjectV2Recent", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Organization":
 		x.Interface = new(Organization)
 	case "Repository":
@@ -28146,6 +28351,10 @@ func (x *PullRequestTimelineItem) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for PullRequestTimelineItem", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "AssignedEvent":
 		x.Interface = new(AssignedEvent)
 	case "BaseRefDeletedEvent":
@@ -28368,6 +28577,10 @@ func (x *PullRequestTimelineItems) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for PullRequestTimelineItems", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "AddedToProjectEvent":
 		x.Interface = new(AddedToProjectEvent)
 	case "AssignedEvent":
@@ -28796,6 +29009,10 @@ func (x *PushAllowanceActor) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for PushAllowanceActor", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "App":
 		x.Interface = new(App)
 	case "Team":
@@ -29161,6 +29378,10 @@ func (x *Reactable) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Reactable", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "CommitComment":
 		x.Interface = new(CommitComment)
 	case "Discussion":
@@ -29411,6 +29632,10 @@ func (x *Reactor) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Reactor", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Bot":
 		x.Interface = new(Bot)
 	case "Mannequin":
@@ -29714,6 +29939,10 @@ func (x *ReferencedSubject) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ReferencedSubject", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Issue":
 		x.Interface = new(Issue)
 	case "PullRequest":
@@ -30478,6 +30707,10 @@ func (x *RenamedTitleSubject) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for RenamedTitleSubject", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Issue":
 		x.Interface = new(Issue)
 	case "PullRequest":
@@ -33578,6 +33811,10 @@ func (x *RepositoryAuditEntryData) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for RepositoryAuditEntryData", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "OrgRestoreMemberMembershipRepositoryAuditEntryData":
 		x.Interface = new(OrgRestoreMemberMembershipRepositoryAuditEntryData)
 	case "PrivateRepositoryForkingDisableAuditEntry":
@@ -33803,6 +34040,10 @@ func (x *RepositoryDiscussionAuthor) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for RepositoryDiscussionAuthor", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Organization":
 		x.Interface = new(Organization)
 	case "User":
@@ -33844,6 +34085,10 @@ func (x *RepositoryDiscussionCommentAuthor) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for RepositoryDiscussionCommentAuthor", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Organization":
 		x.Interface = new(Organization)
 	case "User":
@@ -33923,6 +34168,10 @@ func (x *RepositoryInfo) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for RepositoryInfo", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Repository":
 		x.Interface = new(Repository)
 	}
@@ -34243,6 +34492,10 @@ func (x *RepositoryNode) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for RepositoryNode", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "CommitComment":
 		x.Interface = new(CommitComment)
 	case "CommitCommentThread":
@@ -34343,6 +34596,10 @@ func (x *RepositoryOwner) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for RepositoryOwner", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Organization":
 		x.Interface = new(Organization)
 	case "User":
@@ -34909,6 +35166,10 @@ func (x *RequestedReviewer) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for RequestedReviewer", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Mannequin":
 		x.Interface = new(Mannequin)
 	case "Team":
@@ -34984,6 +35245,10 @@ func (x *RequirableByPullRequest) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for RequirableByPullRequest", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "CheckRun":
 		x.Interface = new(CheckRun)
 	case "StatusContext":
@@ -35152,6 +35417,10 @@ func (x *ReviewDismissalAllowanceActor) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for ReviewDismissalAllowanceActor", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "App":
 		x.Interface = new(App)
 	case "Team":
@@ -35616,6 +35885,10 @@ func (x *SearchResultItem) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for SearchResultItem", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "App":
 		x.Interface = new(App)
 	case "Discussion":
@@ -36306,6 +36579,10 @@ func (x *Sponsor) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Sponsor", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Organization":
 		x.Interface = new(Organization)
 	case "User":
@@ -36416,6 +36693,10 @@ func (x *Sponsorable) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Sponsorable", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Organization":
 		x.Interface = new(Organization)
 	case "User":
@@ -36456,6 +36737,10 @@ func (x *SponsorableItem) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for SponsorableItem", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Organization":
 		x.Interface = new(Organization)
 	case "User":
@@ -37152,6 +37437,10 @@ func (x *Starrable) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Starrable", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Gist":
 		x.Interface = new(Gist)
 	case "Repository":
@@ -37374,6 +37663,10 @@ func (x *StatusCheckRollupContext) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for StatusCheckRollupContext", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "CheckRun":
 		x.Interface = new(CheckRun)
 	case "StatusContext":
@@ -37631,6 +37924,10 @@ func (x *Subscribable) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Subscribable", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Commit":
 		x.Interface = new(Commit)
 	case "Discussion":
@@ -38211,6 +38508,10 @@ func (x *TeamAuditEntryData) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for TeamAuditEntryData", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "OrgRestoreMemberMembershipTeamAuditEntryData":
 		x.Interface = new(OrgRestoreMemberMembershipTeamAuditEntryData)
 	case "TeamAddMemberAuditEntry":
@@ -39330,6 +39631,10 @@ func (x *TopicAuditEntryData) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for TopicAuditEntryData", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "RepoAddTopicAuditEntry":
 		x.Interface = new(RepoAddTopicAuditEntry)
 	case "RepoRemoveTopicAuditEntry":
@@ -39682,6 +39987,10 @@ func (x *UniformResourceLocatable) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for UniformResourceLocatable", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Bot":
 		x.Interface = new(Bot)
 	case "CheckRun":
@@ -40156,6 +40465,10 @@ func (x *Updatable) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Updatable", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "CommitComment":
 		x.Interface = new(CommitComment)
 	case "Discussion":
@@ -40237,6 +40550,10 @@ func (x *UpdatableComment) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for UpdatableComment", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "CommitComment":
 		x.Interface = new(CommitComment)
 	case "DiscussionComment":
@@ -43618,6 +43935,10 @@ func (x *VerifiableDomainOwner) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for VerifiableDomainOwner", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Enterprise":
 		x.Interface = new(Enterprise)
 	case "Organization":
@@ -43702,6 +44023,10 @@ func (x *Votable) UnmarshalJSON(js []byte) error {
 	switch info.Typename {
 	default:
 		return fmt.Errorf("unexpected type %q for Votable", info.Typename)
+	case "":
+		// JSON null: no value.
+		x.Interface = nil
+		return nil
 	case "Discussion":
 		x.Interface = new(Discussion)
 	case "DiscussionComment":
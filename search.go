@@ -0,0 +1,59 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import "net/url"
+
+// A CodeMatch is a file matching a code search.
+type CodeMatch struct {
+	Owner     string
+	Repo      string
+	Path      string
+	Hash      string // blob hash of file
+	URL       string
+	Fragments []string // text fragments containing matches
+}
+
+type codeMatchJSON struct {
+	Path       string `json:"path"`
+	SHA        string `json:"sha"`
+	HTMLURL    string `json:"html_url"`
+	Repository struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+	TextMatches []struct {
+		Property string `json:"property"`
+		Fragment string `json:"fragment"`
+	} `json:"text_matches"`
+}
+
+// SearchCode returns the files matching the code search query,
+// which uses GitHub's code search syntax, as in "Setenv repo:golang/go language:go".
+// GitHub returns at most 1,000 results for a search
+// and limits the rate of code search requests more strictly than other requests.
+func (c *Client) SearchCode(query string) ([]*CodeMatch, error) {
+	path := "/search/code?q=" + url.QueryEscape(query)
+	list, err := restCollectAccept[*codeMatchJSON](c, path, "application/vnd.github.text-match+json", "items")
+	var matches []*CodeMatch
+	for _, j := range list {
+		m := &CodeMatch{
+			Owner: j.Repository.Owner.Login,
+			Repo:  j.Repository.Name,
+			Path:  j.Path,
+			Hash:  j.SHA,
+			URL:   j.HTMLURL,
+		}
+		for _, t := range j.TextMatches {
+			if t.Property == "content" {
+				m.Fragments = append(m.Fragments, t.Fragment)
+			}
+		}
+		matches = append(matches, m)
+	}
+	return matches, err
+}
@@ -0,0 +1,421 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"rsc.io/github/schema"
+)
+
+// A DependabotAlert is an alert that a repository depends on
+// a package version with a known vulnerability.
+type DependabotAlert struct {
+	ID              string
+	Number          int
+	Owner           string
+	Repo            string
+	State           schema.RepositoryVulnerabilityAlertState // OPEN, FIXED, or DISMISSED
+	Severity        schema.SecurityAdvisorySeverity          // LOW, MODERATE, HIGH, or CRITICAL
+	Ecosystem       schema.SecurityAdvisoryEcosystem
+	Package         string
+	Manifest        string // path to manifest file, like "go.mod"
+	Requirements    string // requirement in manifest, like "= 0.3.7"
+	VulnerableRange string // vulnerable versions, like "< 0.3.8"
+	PatchedVersion  string // first patched version, if any
+	Advisory        string // GHSA ID
+	CVE             string // CVE ID, if any
+	Summary         string
+	URL             string // advisory permalink
+	CreatedAt       time.Time
+	DismissedAt     time.Time
+	DismissReason   string
+	FixedAt         time.Time
+}
+
+func toDependabotAlert(s *schema.RepositoryVulnerabilityAlert) *DependabotAlert {
+	a := &DependabotAlert{
+		ID:            string(s.Id),
+		Number:        s.Number,
+		State:         s.State,
+		Manifest:      s.VulnerableManifestPath,
+		Requirements:  s.VulnerableRequirements,
+		CreatedAt:     toTime(s.CreatedAt),
+		DismissedAt:   toTime(s.DismissedAt),
+		DismissReason: s.DismissReason,
+		FixedAt:       toTime(s.FixedAt),
+	}
+	if s.Repository != nil {
+		a.Owner = toOwner(&s.Repository.Owner)
+		a.Repo = s.Repository.Name
+	}
+	if v := s.SecurityVulnerability; v != nil {
+		a.Severity = v.Severity
+		a.VulnerableRange = v.VulnerableVersionRange
+		if v.Package != nil {
+			a.Ecosystem = v.Package.Ecosystem
+			a.Package = v.Package.Name
+		}
+		if v.FirstPatchedVersion != nil {
+			a.PatchedVersion = v.FirstPatchedVersion.Identifier
+		}
+	}
+	if adv := s.SecurityAdvisory; adv != nil {
+		a.Advisory = adv.GhsaId
+		a.Summary = adv.Summary
+		a.URL = string(adv.Permalink)
+		for _, id := range adv.Identifiers {
+			if id.Type == "CVE" {
+				a.CVE = id.Value
+			}
+		}
+	}
+	return a
+}
+
+// DependabotAlerts returns the Dependabot alerts for org/repo.
+// If states are listed, only alerts in those states are returned.
+func (c *Client) DependabotAlerts(org, repo string, states ...schema.RepositoryVulnerabilityAlertState) ([]*DependabotAlert, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $States: [RepositoryVulnerabilityAlertState!], $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      vulnerabilityAlerts(first: $PageSize, after: $Cursor, states: $States) {
+	        pageInfo {
+	          hasNextPage
+	          endCursor
+	        }
+	        totalCount
+	        nodes {
+	          id
+	          number
+	          state
+	          vulnerableManifestPath
+	          vulnerableRequirements
+	          createdAt
+	          dismissedAt
+	          dismissReason
+	          fixedAt
+	          repository { name owner { __typename login } }
+	          securityVulnerability {
+	            severity
+	            vulnerableVersionRange
+	            package { ecosystem name }
+	            firstPatchedVersion { identifier }
+	          }
+	          securityAdvisory {
+	            ghsaId
+	            summary
+	            permalink
+	            identifiers { type value }
+	          }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	vars := Vars{"Org": org, "Repo": repo}
+	if len(states) > 0 {
+		vars["States"] = states
+	}
+	return collect(c, graphql, vars, toDependabotAlert,
+		func(q *schema.Query) pager[*schema.RepositoryVulnerabilityAlert] {
+			if q.Repository == nil || q.Repository.VulnerabilityAlerts == nil {
+				return nil
+			}
+			return q.Repository.VulnerabilityAlerts
+		},
+	)
+}
+
+// A SecurityAdvisory is a security advisory published (or being drafted)
+// by a repository's maintainers.
+type SecurityAdvisory struct {
+	ID              string // GHSA ID
+	CVE             string
+	Owner           string
+	Repo            string
+	Summary         string
+	Description     string
+	Severity        string // low, medium, high, or critical
+	State           string // triage, draft, published, or closed
+	URL             string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	PublishedAt     time.Time
+	Vulnerabilities []*AdvisoryVulnerability
+}
+
+// An AdvisoryVulnerability describes a vulnerable package in a security advisory.
+type AdvisoryVulnerability struct {
+	Ecosystem       string
+	Package         string
+	VulnerableRange string // like "< 1.2.3"
+	PatchedVersions string // like "1.2.3"
+}
+
+type securityAdvisoryJSON struct {
+	GhsaID          string    `json:"ghsa_id"`
+	CveID           string    `json:"cve_id"`
+	Summary         string    `json:"summary"`
+	Description     string    `json:"description"`
+	Severity        string    `json:"severity"`
+	State           string    `json:"state"`
+	HTMLURL         string    `json:"html_url"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	PublishedAt     time.Time `json:"published_at"`
+	Vulnerabilities []struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+		VulnerableVersionRange string `json:"vulnerable_version_range"`
+		PatchedVersions        string `json:"patched_versions"`
+	} `json:"vulnerabilities"`
+}
+
+// RepoSecurityAdvisories returns the security advisories for org/repo
+// that are visible to the authenticated user.
+func (c *Client) RepoSecurityAdvisories(org, repo string) ([]*SecurityAdvisory, error) {
+	list, err := restCollect[*securityAdvisoryJSON](c, "/repos/"+org+"/"+repo+"/security-advisories", "")
+	var advs []*SecurityAdvisory
+	for _, j := range list {
+		a := &SecurityAdvisory{
+			ID:          j.GhsaID,
+			CVE:         j.CveID,
+			Owner:       org,
+			Repo:        repo,
+			Summary:     j.Summary,
+			Description: j.Description,
+			Severity:    j.Severity,
+			State:       j.State,
+			URL:         j.HTMLURL,
+			CreatedAt:   j.CreatedAt,
+			UpdatedAt:   j.UpdatedAt,
+			PublishedAt: j.PublishedAt,
+		}
+		for _, v := range j.Vulnerabilities {
+			a.Vulnerabilities = append(a.Vulnerabilities, &AdvisoryVulnerability{
+				Ecosystem:       v.Package.Ecosystem,
+				Package:         v.Package.Name,
+				VulnerableRange: v.VulnerableVersionRange,
+				PatchedVersions: v.PatchedVersions,
+			})
+		}
+		advs = append(advs, a)
+	}
+	return advs, err
+}
+
+// A CodeScanningAlert is an alert from a code scanning tool, such as CodeQL.
+type CodeScanningAlert struct {
+	Number         int
+	Owner          string
+	Repo           string
+	State          string // open, dismissed, or fixed
+	Tool           string
+	Rule           string // rule ID
+	RuleSeverity   string // none, note, warning, or error
+	Severity       string // security severity: low, medium, high, or critical; empty for non-security rules
+	Description    string
+	Ref            string // ref where alert was most recently seen
+	Path           string // file where alert was most recently seen
+	Line           int
+	URL            string
+	CreatedAt      time.Time
+	DismissedAt    time.Time
+	DismissReason  string
+	DismissComment string
+	FixedAt        time.Time
+}
+
+type codeScanningAlertJSON struct {
+	Number           int       `json:"number"`
+	State            string    `json:"state"`
+	HTMLURL          string    `json:"html_url"`
+	CreatedAt        time.Time `json:"created_at"`
+	DismissedAt      time.Time `json:"dismissed_at"`
+	DismissedReason  string    `json:"dismissed_reason"`
+	DismissedComment string    `json:"dismissed_comment"`
+	FixedAt          time.Time `json:"fixed_at"`
+	Rule             struct {
+		ID                    string `json:"id"`
+		Severity              string `json:"severity"`
+		SecuritySeverityLevel string `json:"security_severity_level"`
+		Description           string `json:"description"`
+	} `json:"rule"`
+	Tool struct {
+		Name string `json:"name"`
+	} `json:"tool"`
+	MostRecentInstance struct {
+		Ref      string `json:"ref"`
+		Location struct {
+			Path      string `json:"path"`
+			StartLine int    `json:"start_line"`
+		} `json:"location"`
+	} `json:"most_recent_instance"`
+}
+
+func (a *CodeScanningAlert) update(j *codeScanningAlertJSON) {
+	a.Number = j.Number
+	a.State = j.State
+	a.Tool = j.Tool.Name
+	a.Rule = j.Rule.ID
+	a.RuleSeverity = j.Rule.Severity
+	a.Severity = j.Rule.SecuritySeverityLevel
+	a.Description = j.Rule.Description
+	a.Ref = j.MostRecentInstance.Ref
+	a.Path = j.MostRecentInstance.Location.Path
+	a.Line = j.MostRecentInstance.Location.StartLine
+	a.URL = j.HTMLURL
+	a.CreatedAt = j.CreatedAt
+	a.DismissedAt = j.DismissedAt
+	a.DismissReason = j.DismissedReason
+	a.DismissComment = j.DismissedComment
+	a.FixedAt = j.FixedAt
+}
+
+// CodeScanningAlerts returns the code scanning alerts for org/repo.
+// If state is non-empty, only alerts in that state (open, dismissed, or fixed) are returned.
+func (c *Client) CodeScanningAlerts(org, repo, state string) ([]*CodeScanningAlert, error) {
+	path := "/repos/" + org + "/" + repo + "/code-scanning/alerts"
+	if state != "" {
+		path += "?state=" + url.QueryEscape(state)
+	}
+	list, err := restCollect[*codeScanningAlertJSON](c, path, "")
+	var alerts []*CodeScanningAlert
+	for _, j := range list {
+		a := &CodeScanningAlert{Owner: org, Repo: repo}
+		a.update(j)
+		alerts = append(alerts, a)
+	}
+	return alerts, err
+}
+
+// DismissCodeScanningAlert dismisses the alert.
+// The reason must be "false positive", "won't fix", or "used in tests".
+func (c *Client) DismissCodeScanningAlert(a *CodeScanningAlert, reason, comment string) error {
+	body := map[string]string{"state": "dismissed", "dismissed_reason": reason}
+	if comment != "" {
+		body["dismissed_comment"] = comment
+	}
+	return c.setCodeScanningAlert(a, body)
+}
+
+// ReopenCodeScanningAlert reopens a dismissed alert.
+func (c *Client) ReopenCodeScanningAlert(a *CodeScanningAlert) error {
+	return c.setCodeScanningAlert(a, map[string]string{"state": "open"})
+}
+
+func (c *Client) setCodeScanningAlert(a *CodeScanningAlert, body map[string]string) error {
+	var j codeScanningAlertJSON
+	path := fmt.Sprintf("/repos/%s/%s/code-scanning/alerts/%d", a.Owner, a.Repo, a.Number)
+	if err := c.rest("PATCH", path, body, &j); err != nil {
+		return err
+	}
+	if c.isDryRunREST("PATCH") {
+		// Nothing changed, so there is nothing to update.
+		return nil
+	}
+	a.update(&j)
+	return nil
+}
+
+// A SecretScanningAlert is an alert that a secret, such as an API token,
+// was found in a repository.
+type SecretScanningAlert struct {
+	Number            int
+	Owner             string
+	Repo              string
+	State             string // open or resolved
+	SecretType        string // like "github_personal_access_token"
+	SecretName        string // like "GitHub Personal Access Token"
+	URL               string
+	CreatedAt         time.Time
+	ResolvedAt        time.Time
+	ResolvedBy        string
+	Resolution        string
+	ResolutionComment string
+}
+
+type secretScanningAlertJSON struct {
+	Number                int       `json:"number"`
+	State                 string    `json:"state"`
+	SecretType            string    `json:"secret_type"`
+	SecretTypeDisplayName string    `json:"secret_type_display_name"`
+	HTMLURL               string    `json:"html_url"`
+	CreatedAt             time.Time `json:"created_at"`
+	ResolvedAt            time.Time `json:"resolved_at"`
+	ResolvedBy            *struct {
+		Login string `json:"login"`
+	} `json:"resolved_by"`
+	Resolution        string `json:"resolution"`
+	ResolutionComment string `json:"resolution_comment"`
+}
+
+func (a *SecretScanningAlert) update(j *secretScanningAlertJSON) {
+	a.Number = j.Number
+	a.State = j.State
+	a.SecretType = j.SecretType
+	a.SecretName = j.SecretTypeDisplayName
+	a.URL = j.HTMLURL
+	a.CreatedAt = j.CreatedAt
+	a.ResolvedAt = j.ResolvedAt
+	a.ResolvedBy = ""
+	if j.ResolvedBy != nil {
+		a.ResolvedBy = j.ResolvedBy.Login
+	}
+	a.Resolution = j.Resolution
+	a.ResolutionComment = j.ResolutionComment
+}
+
+// SecretScanningAlerts returns the secret scanning alerts for org/repo.
+// If state is non-empty, only alerts in that state (open or resolved) are returned.
+func (c *Client) SecretScanningAlerts(org, repo, state string) ([]*SecretScanningAlert, error) {
+	path := "/repos/" + org + "/" + repo + "/secret-scanning/alerts"
+	if state != "" {
+		path += "?state=" + url.QueryEscape(state)
+	}
+	list, err := restCollect[*secretScanningAlertJSON](c, path, "")
+	var alerts []*SecretScanningAlert
+	for _, j := range list {
+		a := &SecretScanningAlert{Owner: org, Repo: repo}
+		a.update(j)
+		alerts = append(alerts, a)
+	}
+	return alerts, err
+}
+
+// ResolveSecretScanningAlert resolves the alert.
+// The resolution must be "false_positive", "wont_fix", "revoked", or "used_in_tests".
+func (c *Client) ResolveSecretScanningAlert(a *SecretScanningAlert, resolution, comment string) error {
+	body := map[string]string{"state": "resolved", "resolution": resolution}
+	if comment != "" {
+		body["resolution_comment"] = comment
+	}
+	return c.setSecretScanningAlert(a, body)
+}
+
+// ReopenSecretScanningAlert reopens a resolved alert.
+func (c *Client) ReopenSecretScanningAlert(a *SecretScanningAlert) error {
+	return c.setSecretScanningAlert(a, map[string]string{"state": "open"})
+}
+
+func (c *Client) setSecretScanningAlert(a *SecretScanningAlert, body map[string]string) error {
+	var j secretScanningAlertJSON
+	path := fmt.Sprintf("/repos/%s/%s/secret-scanning/alerts/%d", a.Owner, a.Repo, a.Number)
+	if err := c.rest("PATCH", path, body, &j); err != nil {
+		return err
+	}
+	if c.isDryRunREST("PATCH") {
+		// Nothing changed, so there is nothing to update.
+		return nil
+	}
+	a.update(&j)
+	return nil
+}
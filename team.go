@@ -0,0 +1,212 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"fmt"
+
+	"rsc.io/github/schema"
+)
+
+const teamFields = `
+  id
+  slug
+  name
+  description
+  privacy
+  url
+  organization { login }
+  parentTeam { slug }
+`
+
+// A Team is a team in an organization.
+type Team struct {
+	ID          string
+	Org         string
+	Slug        string // name used in URLs and @-mentions, as in @golang/release
+	Name        string
+	Description string
+	Privacy     schema.TeamPrivacy
+	Parent      string // slug of parent team, if any
+	URL         string
+}
+
+func toTeam(s *schema.Team) *Team {
+	t := &Team{
+		ID:          string(s.Id),
+		Slug:        s.Slug,
+		Name:        s.Name,
+		Description: s.Description,
+		Privacy:     s.Privacy,
+		URL:         string(s.Url),
+	}
+	if s.Organization != nil {
+		t.Org = s.Organization.Login
+	}
+	if s.ParentTeam != nil {
+		t.Parent = s.ParentTeam.Slug
+	}
+	return t
+}
+
+// Teams returns the teams in the organization visible to the authenticated user.
+func (c *Client) Teams(org string) ([]*Team, error) {
+	graphql := `
+	  query($Org: String!, $Cursor: String, $PageSize: Int = 100) {
+	    organization(login: $Org) {
+	      teams(first: $PageSize, after: $Cursor) {
+	        pageInfo {
+	          hasNextPage
+	          endCursor
+	        }
+	        totalCount
+	        nodes {
+	          ` + teamFields + `
+	        }
+	      }
+	    }
+	  }
+	`
+
+	return collect(c, graphql, Vars{"Org": org}, toTeam,
+		func(q *schema.Query) pager[*schema.Team] {
+			if q.Organization == nil || q.Organization.Teams == nil {
+				return nil
+			}
+			return q.Organization.Teams
+		},
+	)
+}
+
+// Team returns the team in org with the given slug.
+func (c *Client) Team(org, team string) (*Team, error) {
+	graphql := `
+	  query($Org: String!, $Team: String!) {
+	    organization(login: $Org) {
+	      team(slug: $Team) {
+	        ` + teamFields + `
+	      }
+	    }
+	  }
+	`
+
+	q, err := c.GraphQLQuery(graphql, Vars{"Org": org, "Team": team})
+	if err != nil {
+		return nil, err
+	}
+	if q.Organization == nil || q.Organization.Team == nil {
+		return nil, fmt.Errorf("%s: no team %s", org, team)
+	}
+	return toTeam(q.Organization.Team), nil
+}
+
+// A TeamMember is a member of a team.
+type TeamMember struct {
+	Login string
+	Name  string
+	Role  schema.TeamMemberRole // MAINTAINER or MEMBER
+}
+
+// TeamMembers returns the members of the team in org with the given slug,
+// including members of its child teams.
+func (c *Client) TeamMembers(org, team string) ([]*TeamMember, error) {
+	graphql := `
+	  query($Org: String!, $Team: String!, $Cursor: String, $PageSize: Int = 100) {
+	    organization(login: $Org) {
+	      team(slug: $Team) {
+	        members(first: $PageSize, after: $Cursor) {
+	          pageInfo {
+	            hasNextPage
+	            endCursor
+	          }
+	          totalCount
+	          edges {
+	            role
+	            node { login name }
+	          }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	toTeamMember := func(e *schema.TeamMemberEdge) *TeamMember {
+		m := &TeamMember{Role: e.Role}
+		if e.Node != nil {
+			m.Login = e.Node.Login
+			m.Name = e.Node.Name
+		}
+		return m
+	}
+	var noTeam error
+	list, err := collect(c, graphql, Vars{"Org": org, "Team": team}, toTeamMember,
+		func(q *schema.Query) pager[*schema.TeamMemberEdge] {
+			if q.Organization == nil || q.Organization.Team == nil || q.Organization.Team.Members == nil {
+				noTeam = fmt.Errorf("%s: no team %s", org, team)
+				return nil
+			}
+			m := q.Organization.Team.Members
+			return &edges[*schema.TeamMemberEdge]{m.PageInfo, m.Edges}
+		},
+	)
+	if err == nil {
+		err = noTeam
+	}
+	return list, err
+}
+
+// A TeamRepo is a repository a team has access to.
+type TeamRepo struct {
+	Repo       *Repo
+	Permission schema.RepositoryPermission // ADMIN, MAINTAIN, WRITE, TRIAGE, or READ
+}
+
+// TeamRepos returns the repositories that the team in org with the given slug can access.
+func (c *Client) TeamRepos(org, team string) ([]*TeamRepo, error) {
+	graphql := `
+	  query($Org: String!, $Team: String!, $Cursor: String, $PageSize: Int = 100) {
+	    organization(login: $Org) {
+	      team(slug: $Team) {
+	        repositories(first: $PageSize, after: $Cursor) {
+	          pageInfo {
+	            hasNextPage
+	            endCursor
+	          }
+	          totalCount
+	          edges {
+	            permission
+	            node {
+	              ` + repoFields + `
+	            }
+	          }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	toTeamRepo := func(e *schema.TeamRepositoryEdge) *TeamRepo {
+		r := &TeamRepo{Permission: e.Permission}
+		if e.Node != nil {
+			r.Repo = toRepo(e.Node)
+		}
+		return r
+	}
+	var noTeam error
+	list, err := collect(c, graphql, Vars{"Org": org, "Team": team}, toTeamRepo,
+		func(q *schema.Query) pager[*schema.TeamRepositoryEdge] {
+			if q.Organization == nil || q.Organization.Team == nil || q.Organization.Team.Repositories == nil {
+				noTeam = fmt.Errorf("%s: no team %s", org, team)
+				return nil
+			}
+			r := q.Organization.Team.Repositories
+			return &edges[*schema.TeamRepositoryEdge]{r.PageInfo, r.Edges}
+		},
+	)
+	if err == nil {
+		err = noTeam
+	}
+	return list, err
+}
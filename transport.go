@@ -0,0 +1,71 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+)
+
+// By default, a Client uses [http.DefaultClient], which connects through
+// the proxy named by the $HTTPS_PROXY environment variable, if any,
+// and verifies servers using the system's certificate roots.
+// WithProxy and WithTLSConfig override those settings,
+// for environments where the defaults do not work,
+// such as networks that require a proxy for some programs but not others,
+// or that intercept TLS connections using a private certificate authority.
+
+// WithProxy returns a shallow copy of c that connects through the HTTP(S) proxy u,
+// as in "http://proxy.example.com:3128".
+// If u is nil, the copy uses the proxy named by the environment, if any.
+// WithProxy replaces the Transport of c's HTTP client (see [Client.WithHTTPClient])
+// with a modified copy, or with a copy of [http.DefaultTransport]
+// if that Transport is not an [*http.Transport].
+func (c *Client) WithProxy(u *url.URL) *Client {
+	t := c.transport()
+	if u == nil {
+		t.Proxy = http.ProxyFromEnvironment
+	} else {
+		t.Proxy = http.ProxyURL(u)
+	}
+	return c.withTransport(t)
+}
+
+// WithTLSConfig returns a shallow copy of c that uses cfg for TLS connections.
+// For example, to trust a corporate certificate authority in addition to the system roots:
+//
+//	pool, err := x509.SystemCertPool()
+//	...
+//	pem, err := os.ReadFile("/etc/corp/ca.pem")
+//	...
+//	pool.AppendCertsFromPEM(pem)
+//	c = c.WithTLSConfig(&tls.Config{RootCAs: pool})
+//
+// Like [Client.WithProxy], WithTLSConfig replaces the Transport of c's HTTP client
+// with a modified copy.
+func (c *Client) WithTLSConfig(cfg *tls.Config) *Client {
+	t := c.transport()
+	t.TLSClientConfig = cfg
+	return c.withTransport(t)
+}
+
+// transport returns a copy of the *http.Transport used by c,
+// or of http.DefaultTransport if c's transport is not an *http.Transport.
+func (c *Client) transport() *http.Transport {
+	if t, ok := c.HTTPClient().Transport.(*http.Transport); ok {
+		return t.Clone()
+	}
+	return http.DefaultTransport.(*http.Transport).Clone()
+}
+
+// withTransport returns a shallow copy of c using an HTTP client like c's
+// but with transport t.
+func (c *Client) withTransport(t *http.Transport) *Client {
+	hc := new(http.Client)
+	*hc = *c.HTTPClient()
+	hc.Transport = t
+	return c.WithHTTPClient(hc)
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// WithVarsCheck returns a shallow copy of c that, if check is true,
+// validates the variables passed with each GraphQL query or mutation
+// against the variables the operation declares, before sending it.
+// A mistake, such as a misspelled variable name, an undeclared variable,
+// or a missing or nil value (including a nil slice)
+// for a non-null variable without a default,
+// is reported as an error instead of being sent to GitHub.
+// Tools and tests can enable the check to catch mistakes early.
+func (c *Client) WithVarsCheck(check bool) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.checkVars = check
+	return c2
+}
+
+// A varDecl is a variable declaration in a GraphQL operation.
+type varDecl struct {
+	typ        string // type, as in "String!" or "[ID!]"
+	hasDefault bool
+}
+
+var varDeclRE = regexp.MustCompile(`\$(\w+)\s*:\s*(\[[^\]]*\]!?|\w+!?)\s*(=)?`)
+
+// declaredVars returns the variables declared by the GraphQL operation query.
+func declaredVars(query string) map[string]varDecl {
+	q := strings.TrimSpace(query)
+	i := strings.Index(q, "(")
+	if i < 0 || strings.Contains(q[:i], "{") {
+		return nil
+	}
+	end := matching(q, i)
+	if end < 0 {
+		return nil
+	}
+	decls := make(map[string]varDecl)
+	for _, m := range varDeclRE.FindAllStringSubmatch(q[i+1:end], -1) {
+		decls[m[1]] = varDecl{typ: m[2], hasDefault: m[3] != ""}
+	}
+	return decls
+}
+
+// validateVars checks vars against the variables declared by query.
+func validateVars(query string, vars Vars) error {
+	decls := declaredVars(query)
+	var errs []string
+	for name := range vars {
+		if _, ok := decls[name]; !ok {
+			errs = append(errs, fmt.Sprintf("variable $%s is not declared", name))
+		}
+	}
+	for name, d := range decls {
+		if !strings.HasSuffix(d.typ, "!") || d.hasDefault {
+			continue
+		}
+		if v, ok := vars[name]; !ok {
+			errs = append(errs, fmt.Sprintf("missing value for $%s: %s", name, d.typ))
+		} else if isNil(v) {
+			errs = append(errs, fmt.Sprintf("nil value for $%s: %s", name, d.typ))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Strings(errs)
+	return fmt.Errorf("github: invalid query variables: %s", strings.Join(errs, "; "))
+}
+
+// isNil reports whether v encodes as JSON null.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,317 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"rsc.io/github/schema"
+)
+
+// WatchOptions holds options for [Client.Watch].
+type WatchOptions struct {
+	// Since is the time from which to report changes,
+	// if there is no checkpoint to resume from.
+	// The zero time reports every issue in the repository on the first poll.
+	Since time.Time
+
+	// Interval is the time between polls. The default is one minute.
+	Interval time.Duration
+
+	// Checkpoint, if non-empty, names a file in which Watch saves
+	// the time of the latest change it has delivered, after each poll.
+	// If the file exists when Watch starts, Watch resumes from that time,
+	// ignoring Since, so that a restarted program neither misses
+	// nor repeats changes.
+	Checkpoint string
+}
+
+// A Change is a change to a repository reported by [Client.Watch].
+type Change struct {
+	Issue   *Issue        // issue that changed, or the issue being commented on
+	Comment *IssueComment // new or edited comment, if the change is to a comment
+	Event   *IssueEvent   // new timeline event, if the change is an event such as labeling
+	Err     error         // error polling for changes; other fields are nil
+}
+
+// An IssueEvent is an event in an issue's timeline, such as
+// adding a label or closing the issue.
+type IssueEvent struct {
+	ID        string
+	Type      string // GraphQL type name, such as "LabeledEvent" or "ClosedEvent"
+	Actor     string // login of the user who caused the event
+	CreatedAt time.Time
+	Label     string // label name, for LabeledEvent and UnlabeledEvent
+	Milestone string // milestone title, for MilestonedEvent and DemilestonedEvent
+	Assignee  string // login of the assignee, for AssignedEvent and UnassignedEvent
+	Title     string // new title, for RenamedTitleEvent
+}
+
+// Watch polls org/repo for changes to issues and issue comments,
+// delivering them on the returned channel, oldest first.
+// Each issue that was created or changed (including by
+// labeling, closing, or editing) is delivered as a Change with Comment nil,
+// followed by a Change for each new or edited comment on that issue
+// and then a Change for each new event in the issue's timeline
+// (labeled, unlabeled, closed, reopened, assigned, unassigned,
+// milestoned, demilestoned, and renamed).
+//
+// Watch polls until c's context (see [Client.WithContext]) is canceled,
+// and then it closes the channel. Errors polling GitHub are
+// delivered as a Change with Err set; Watch then tries again at the next poll.
+// The caller must receive from the channel promptly, since Watch
+// does not poll again until the previous poll's changes have been received.
+func (c *Client) Watch(org, repo string, opts *WatchOptions) <-chan *Change {
+	if opts == nil {
+		opts = new(WatchOptions)
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 1 * time.Minute
+	}
+	since := opts.Since
+	if opts.Checkpoint != "" {
+		if t, err := readCheckpoint(opts.Checkpoint); err == nil {
+			since = t
+		}
+	}
+
+	ch := make(chan *Change)
+	go func() {
+		defer close(ch)
+		ctx := c.context()
+		send := func(x *Change) bool {
+			select {
+			case ch <- x:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// The since filter is inclusive, so each poll reports again
+		// the changes made at exactly the checkpoint time.
+		// seen records those changes, to avoid repeating them.
+		seen := make(map[string]bool)
+		for {
+			issues, err := c.issuesSince(org, repo, since)
+			if err != nil && !send(&Change{Err: err}) {
+				return
+			}
+			next := since
+			delivered := make(map[string]time.Time)
+			for _, issue := range issues {
+				var comments []*IssueComment
+				if issue.CommentCount > 0 {
+					comments, err = c.IssueComments(issue)
+					if err != nil {
+						if !send(&Change{Err: err}) {
+							return
+						}
+						break
+					}
+				}
+				var events []*IssueEvent
+				events, err = c.issueEvents(issue, since)
+				if err != nil {
+					if !send(&Change{Err: err}) {
+						return
+					}
+					break
+				}
+				key := issue.ID + "@" + issue.UpdatedAt.String()
+				if !seen[key] {
+					if !send(&Change{Issue: issue}) {
+						return
+					}
+					delivered[key] = issue.UpdatedAt
+				}
+				for _, cm := range comments {
+					key := cm.ID + "@" + cm.UpdatedAt.String()
+					if cm.UpdatedAt.Before(since) || seen[key] {
+						continue
+					}
+					if !send(&Change{Issue: issue, Comment: cm}) {
+						return
+					}
+					delivered[key] = cm.UpdatedAt
+				}
+				for _, ev := range events {
+					key := ev.ID + "@" + ev.CreatedAt.String()
+					if ev.CreatedAt.Before(since) || seen[key] {
+						continue
+					}
+					if !send(&Change{Issue: issue, Event: ev}) {
+						return
+					}
+					delivered[key] = ev.CreatedAt
+				}
+				if issue.UpdatedAt.After(next) {
+					next = issue.UpdatedAt
+				}
+			}
+			if next.After(since) {
+				since = next
+				seen = make(map[string]bool)
+			}
+			for key, t := range delivered {
+				if t.Equal(since) {
+					seen[key] = true
+				}
+			}
+			if opts.Checkpoint != "" && err == nil {
+				if err := writeCheckpoint(opts.Checkpoint, since); err != nil && !send(&Change{Err: err}) {
+					return
+				}
+			}
+			if c.sleep(interval) != nil {
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// issuesSince returns the issues in org/repo updated at or after since,
+// in order of last update.
+func (c *Client) issuesSince(org, repo string, since time.Time) ([]*Issue, error) {
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Since: DateTime!, $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      issues(first: $PageSize, after: $Cursor, filterBy: {since: $Since}, orderBy: {field: UPDATED_AT, direction: ASC}) {
+	        pageInfo {
+	          hasNextPage
+	          endCursor
+	        }
+	        totalCount
+	        nodes {
+	          ` + issueFields + `
+	        }
+	      }
+	    }
+	  }
+	`
+
+	vars := Vars{"Org": org, "Repo": repo, "Since": since.UTC().Format(time.RFC3339)}
+	list, err := collect(c, graphql, vars, toIssue,
+		func(q *schema.Query) pager[*schema.Issue] {
+			if q.Repository == nil || q.Repository.Issues == nil {
+				return nil
+			}
+			return q.Repository.Issues
+		},
+	)
+	if err != nil {
+		return list, err
+	}
+	return list, c.moreIssueLabels(list)
+}
+
+// issueEvents returns the events in the timeline of issue
+// created at or after since, oldest first.
+func (c *Client) issueEvents(issue *Issue, since time.Time) ([]*IssueEvent, error) {
+	// The timeline is a union of many event types; decode only the fields
+	// used by IssueEvent instead of going through the schema package.
+	graphql := `
+	  query($Org: String!, $Repo: String!, $Number: Int!, $Since: DateTime!, $Cursor: String, $PageSize: Int = 100) {
+	    repository(owner: $Org, name: $Repo) {
+	      issue(number: $Number) {
+	        timelineItems(first: $PageSize, after: $Cursor, since: $Since,
+	            itemTypes: [LABELED_EVENT, UNLABELED_EVENT, CLOSED_EVENT, REOPENED_EVENT,
+	              ASSIGNED_EVENT, UNASSIGNED_EVENT, MILESTONED_EVENT, DEMILESTONED_EVENT, RENAMED_TITLE_EVENT]) {
+	          pageInfo {
+	            hasNextPage
+	            endCursor
+	          }
+	          nodes {
+	            __typename
+	            ... on LabeledEvent { id actor { login } createdAt label { name } }
+	            ... on UnlabeledEvent { id actor { login } createdAt label { name } }
+	            ... on ClosedEvent { id actor { login } createdAt }
+	            ... on ReopenedEvent { id actor { login } createdAt }
+	            ... on AssignedEvent { id actor { login } createdAt assignee { ... on Actor { login } } }
+	            ... on UnassignedEvent { id actor { login } createdAt assignee { ... on Actor { login } } }
+	            ... on MilestonedEvent { id actor { login } createdAt milestoneTitle }
+	            ... on DemilestonedEvent { id actor { login } createdAt milestoneTitle }
+	            ... on RenamedTitleEvent { id actor { login } createdAt currentTitle }
+	          }
+	        }
+	      }
+	    }
+	  }
+	`
+
+	type login struct{ Login string }
+	type event struct {
+		Typename       string `json:"__typename"`
+		ID             string
+		Actor          *login
+		CreatedAt      time.Time
+		Label          *struct{ Name string }
+		Assignee       *login
+		MilestoneTitle string
+		CurrentTitle   string
+	}
+	type reply struct {
+		Repository *struct {
+			Issue *struct {
+				TimelineItems *connection[*event]
+			}
+		}
+	}
+
+	vars := Vars{"Org": issue.Owner, "Repo": issue.Repo, "Number": issue.Number, "Since": since.UTC().Format(time.RFC3339)}
+	return collectReply(c.WithLimit(0), graphql, vars,
+		func(e *event) *IssueEvent {
+			ev := &IssueEvent{
+				ID:        e.ID,
+				Type:      e.Typename,
+				CreatedAt: e.CreatedAt,
+				Milestone: e.MilestoneTitle,
+				Title:     e.CurrentTitle,
+			}
+			if e.Actor != nil {
+				ev.Actor = e.Actor.Login
+			}
+			if e.Label != nil {
+				ev.Label = e.Label.Name
+			}
+			if e.Assignee != nil {
+				ev.Assignee = e.Assignee.Login
+			}
+			return ev
+		},
+		func(q *reply) pager[*event] {
+			if q.Repository == nil || q.Repository.Issue == nil || q.Repository.Issue.TimelineItems == nil {
+				return nil
+			}
+			return q.Repository.Issue.TimelineItems
+		},
+	)
+}
+
+func readCheckpoint(file string) (time.Time, error) {
+	var cp struct{ Since time.Time }
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return time.Time{}, err
+	}
+	err = json.Unmarshal(data, &cp)
+	return cp.Since, err
+}
+
+func writeCheckpoint(file string, since time.Time) error {
+	js, err := json.Marshal(struct{ Since time.Time }{since})
+	if err != nil {
+		return err
+	}
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, append(js, '\n'), 0666); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"rsc.io/github"
+	"rsc.io/github/githubtest"
+)
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := githubtest.Client(t, "testdata/watch.json").WithContext(ctx)
+	opts := &github.WatchOptions{
+		Since:    time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Interval: time.Hour,
+	}
+	ch := c.Watch("rsc", "quote", opts)
+
+	want := []string{
+		"issue #5",
+		"comment IC_1 by gopher",
+		"LabeledEvent LE_1 by gopher label=bug",
+		"ClosedEvent CE_1 by  label=",
+	}
+	for _, w := range want {
+		x, ok := <-ch
+		if !ok {
+			t.Fatalf("channel closed early, want %s", w)
+		}
+		var got string
+		switch {
+		case x.Err != nil:
+			t.Fatal(x.Err)
+		case x.Comment != nil:
+			got = fmt.Sprintf("comment %s by %s", x.Comment.ID, x.Comment.Author)
+		case x.Event != nil:
+			got = fmt.Sprintf("%s %s by %s label=%s", x.Event.Type, x.Event.ID, x.Event.Actor, x.Event.Label)
+		default:
+			got = fmt.Sprintf("issue #%d", x.Issue.Number)
+		}
+		if got != w {
+			t.Errorf("change = %s, want %s", got, w)
+		}
+	}
+
+	cancel()
+	for x := range ch {
+		t.Errorf("unexpected change after cancel: %+v", x)
+	}
+}
@@ -0,0 +1,336 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package webhook implements an HTTP handler for GitHub webhook deliveries.
+//
+// A Handler verifies each delivery's signature, parses the payload,
+// and dispatches it to the functions registered for its event type.
+// Issue, comment, and label payloads are converted to the types
+// used by package github, so that code can handle pushed events
+// the same way it handles the results of queries.
+//
+// For example:
+//
+//	h := webhook.NewHandler(os.Getenv("WEBHOOK_SECRET"))
+//	h.HandleIssueComment(func(e *webhook.IssueCommentEvent) error {
+//		log.Printf("%s/%s#%d: new comment by %s", e.Owner, e.Repo, e.Issue.Number, e.Comment.Author)
+//		return nil
+//	})
+//	http.Handle("/webhook", h)
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"rsc.io/github"
+	"rsc.io/github/schema"
+)
+
+// maxPayload is the maximum size of a webhook payload.
+// GitHub caps payloads at 25 MB.
+const maxPayload = 25 << 20
+
+// An Event is a single webhook delivery.
+type Event struct {
+	Type     string // event type, from the X-GitHub-Event header, as in "issues"
+	Delivery string // unique delivery ID, from the X-GitHub-Delivery header
+	Action   string // action, as in "opened" or "labeled"; empty for events without actions
+	Sender   string // login of the user who triggered the event
+	Owner    string // owner of the repository, if any
+	Repo     string // name of the repository, if any
+	Payload  []byte // raw JSON payload
+}
+
+// An IssuesEvent is an "issues" event: an issue was opened, edited, closed, labeled, and so on.
+type IssuesEvent struct {
+	*Event
+	Issue *github.Issue
+	Label *github.Label // for "labeled" and "unlabeled" actions
+}
+
+// An IssueCommentEvent is an "issue_comment" event:
+// a comment on an issue or pull request was created, edited, or deleted.
+type IssueCommentEvent struct {
+	*Event
+	Issue   *github.Issue
+	Comment *github.IssueComment
+}
+
+// A LabelEvent is a "label" event: a repository label was created, edited, or deleted.
+type LabelEvent struct {
+	*Event
+	Label *github.Label
+}
+
+// A Handler is an [http.Handler] that receives webhook deliveries.
+type Handler struct {
+	secret   []byte
+	insecure bool // accept deliveries without checking signatures
+
+	mu       sync.Mutex
+	handlers map[string][]func(*Event) error
+}
+
+// NewHandler returns a new Handler that verifies deliveries
+// using the webhook secret. Deliveries without a valid
+// X-Hub-Signature-256 header are rejected.
+// NewHandler panics if secret is empty, so that a missing
+// configuration setting cannot turn off verification;
+// use [NewInsecureHandler] to accept unsigned deliveries.
+func NewHandler(secret string) *Handler {
+	if secret == "" {
+		panic("webhook: NewHandler with empty secret")
+	}
+	return &Handler{secret: []byte(secret), handlers: make(map[string][]func(*Event) error)}
+}
+
+// NewInsecureHandler returns a new Handler that accepts all deliveries
+// without verifying their signatures, which is appropriate only for testing.
+func NewInsecureHandler() *Handler {
+	return &Handler{insecure: true, handlers: make(map[string][]func(*Event) error)}
+}
+
+// Handle registers f to be called for each delivery of the given event type,
+// as in "push" or "pull_request". Use "*" to receive all events.
+// Handle is for event types without typed handlers;
+// f can decode e.Payload itself.
+func (h *Handler) Handle(eventType string, f func(e *Event) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[eventType] = append(h.handlers[eventType], f)
+}
+
+// HandleIssues registers f to be called for each "issues" event.
+func (h *Handler) HandleIssues(f func(*IssuesEvent) error) {
+	h.Handle("issues", func(e *Event) error {
+		var p payload
+		if err := json.Unmarshal(e.Payload, &p); err != nil {
+			return err
+		}
+		return f(&IssuesEvent{Event: e, Issue: p.Issue.toIssue(&p), Label: p.Label.toLabel(&p)})
+	})
+}
+
+// HandleIssueComment registers f to be called for each "issue_comment" event.
+func (h *Handler) HandleIssueComment(f func(*IssueCommentEvent) error) {
+	h.Handle("issue_comment", func(e *Event) error {
+		var p payload
+		if err := json.Unmarshal(e.Payload, &p); err != nil {
+			return err
+		}
+		return f(&IssueCommentEvent{Event: e, Issue: p.Issue.toIssue(&p), Comment: p.Comment.toComment(&p)})
+	})
+}
+
+// HandleLabel registers f to be called for each "label" event.
+func (h *Handler) HandleLabel(f func(*LabelEvent) error) {
+	h.Handle("label", func(e *Event) error {
+		var p payload
+		if err := json.Unmarshal(e.Payload, &p); err != nil {
+			return err
+		}
+		return f(&LabelEvent{Event: e, Label: p.Label.toLabel(&p)})
+	})
+}
+
+// ServeHTTP implements [http.Handler].
+// It responds with status 200 if all handlers for the delivery succeed,
+// 400 if the delivery is malformed, 401 if its signature is invalid,
+// and 500 if a handler returns an error.
+// GitHub does not retry failed deliveries automatically,
+// but they can be redelivered from the repository's webhook settings.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxPayload+1))
+	if err != nil {
+		http.Error(w, "reading body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(data) > maxPayload {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if !h.verify(r.Header.Get("X-Hub-Signature-256"), data) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	if ct := r.Header.Get("Content-Type"); ct != "" && !strings.HasPrefix(ct, "application/json") {
+		http.Error(w, "webhook content type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	e := &Event{
+		Type:     r.Header.Get("X-GitHub-Event"),
+		Delivery: r.Header.Get("X-GitHub-Delivery"),
+		Payload:  data,
+	}
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		http.Error(w, "parsing payload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	e.Action = p.Action
+	e.Sender = p.Sender.Login
+	e.Owner = p.Repository.Owner.Login
+	e.Repo = p.Repository.Name
+
+	h.mu.Lock()
+	var handlers []func(*Event) error
+	handlers = append(handlers, h.handlers[e.Type]...)
+	handlers = append(handlers, h.handlers["*"]...)
+	h.mu.Unlock()
+	for _, f := range handlers {
+		if err := f(e); err != nil {
+			http.Error(w, fmt.Sprintf("%s event: %v", e.Type, err), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// verify reports whether sig is a valid X-Hub-Signature-256 header for data.
+func (h *Handler) verify(sig string, data []byte) bool {
+	if h.insecure {
+		return true
+	}
+	hexSum, ok := strings.CutPrefix(sig, "sha256=")
+	if !ok {
+		return false
+	}
+	sum, err := hex.DecodeString(hexSum)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(data)
+	return hmac.Equal(sum, mac.Sum(nil))
+}
+
+// A payload holds the parts of a webhook payload used by the typed handlers.
+type payload struct {
+	Action     string `json:"action"`
+	Sender     user   `json:"sender"`
+	Repository struct {
+		Name  string `json:"name"`
+		Owner user   `json:"owner"`
+	} `json:"repository"`
+	Issue   *issue   `json:"issue"`
+	Comment *comment `json:"comment"`
+	Label   *label   `json:"label"`
+}
+
+type user struct {
+	Login string `json:"login"`
+}
+
+type issue struct {
+	NodeID      string     `json:"node_id"`
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	State       string     `json:"state"`
+	StateReason string     `json:"state_reason"`
+	User        user       `json:"user"`
+	Body        string     `json:"body"`
+	HTMLURL     string     `json:"html_url"`
+	Comments    int        `json:"comments"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ClosedAt    *time.Time `json:"closed_at"`
+	Labels      []*label   `json:"labels"`
+	Milestone   *struct {
+		NodeID string `json:"node_id"`
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	} `json:"milestone"`
+}
+
+func (j *issue) toIssue(p *payload) *github.Issue {
+	if j == nil {
+		return nil
+	}
+	i := &github.Issue{
+		ID:           j.NodeID,
+		Title:        j.Title,
+		Number:       j.Number,
+		Closed:       j.State == "closed",
+		StateReason:  schema.IssueStateReason(strings.ToUpper(j.StateReason)),
+		CreatedAt:    j.CreatedAt,
+		UpdatedAt:    j.UpdatedAt,
+		Author:       j.User.Login,
+		Owner:        p.Repository.Owner.Login,
+		Repo:         p.Repository.Name,
+		Body:         j.Body,
+		URL:          j.HTMLURL,
+		CommentCount: j.Comments,
+	}
+	if j.ClosedAt != nil {
+		i.ClosedAt = *j.ClosedAt
+	}
+	for _, l := range j.Labels {
+		i.Labels = append(i.Labels, l.toLabel(p))
+	}
+	if m := j.Milestone; m != nil {
+		i.Milestone = &github.Milestone{ID: m.NodeID, Number: m.Number, Title: m.Title}
+	}
+	return i
+}
+
+type comment struct {
+	NodeID    string    `json:"node_id"`
+	User      user      `json:"user"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (j *comment) toComment(p *payload) *github.IssueComment {
+	if j == nil {
+		return nil
+	}
+	c := &github.IssueComment{
+		ID:          j.NodeID,
+		Author:      j.User.Login,
+		Body:        j.Body,
+		CreatedAt:   j.CreatedAt,
+		PublishedAt: j.CreatedAt,
+		UpdatedAt:   j.UpdatedAt,
+		Owner:       p.Repository.Owner.Login,
+		Repo:        p.Repository.Name,
+	}
+	if p.Issue != nil {
+		c.Issue = p.Issue.Number
+	}
+	return c
+}
+
+type label struct {
+	NodeID      string `json:"node_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (j *label) toLabel(p *payload) *github.Label {
+	if j == nil {
+		return nil
+	}
+	return &github.Label{
+		ID:          j.NodeID,
+		Name:        j.Name,
+		Description: j.Description,
+		Owner:       p.Repository.Owner.Login,
+		Repo:        p.Repository.Name,
+	}
+}
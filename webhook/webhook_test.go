@@ -0,0 +1,112 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package webhook_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"rsc.io/github/webhook"
+)
+
+const testSecret = "It's a Secret to Everybody"
+
+const issuesPayload = `{
+	"action": "opened",
+	"sender": {"login": "gopher"},
+	"repository": {"name": "hello", "owner": {"login": "octo"}},
+	"issue": {"node_id": "I_1", "number": 7, "title": "a bug", "state": "open", "user": {"login": "gopher"}}
+}`
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliver(h http.Handler, sig, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("POST", "/webhook", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-GitHub-Event", "issues")
+	r.Header.Set("X-GitHub-Delivery", "1")
+	if sig != "" {
+		r.Header.Set("X-Hub-Signature-256", sig)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestSignature(t *testing.T) {
+	var tests = []struct {
+		name string
+		sig  string
+		code int
+	}{
+		{"valid", sign(testSecret, issuesPayload), http.StatusOK},
+		{"missing", "", http.StatusUnauthorized},
+		{"wrong secret", sign("not the secret", issuesPayload), http.StatusUnauthorized},
+		{"wrong body", sign(testSecret, issuesPayload+" "), http.StatusUnauthorized},
+		{"no prefix", strings.TrimPrefix(sign(testSecret, issuesPayload), "sha256="), http.StatusUnauthorized},
+		{"sha1", "sha1=" + strings.TrimPrefix(sign(testSecret, issuesPayload), "sha256="), http.StatusUnauthorized},
+		{"bad hex", "sha256=xyz", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := webhook.NewHandler(testSecret)
+			called := 0
+			h.HandleIssues(func(e *webhook.IssuesEvent) error {
+				called++
+				if e.Action != "opened" || e.Owner != "octo" || e.Repo != "hello" || e.Sender != "gopher" {
+					t.Errorf("event = %+v, want opened octo/hello by gopher", e.Event)
+				}
+				if e.Issue == nil || e.Issue.Number != 7 || e.Issue.Title != "a bug" {
+					t.Errorf("issue = %+v, want #7 a bug", e.Issue)
+				}
+				return nil
+			})
+			w := deliver(h, tt.sig, issuesPayload)
+			if w.Code != tt.code {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.code, strings.TrimSpace(w.Body.String()))
+			}
+			want := 0
+			if tt.code == http.StatusOK {
+				want = 1
+			}
+			if called != want {
+				t.Errorf("handler called %d times, want %d", called, want)
+			}
+		})
+	}
+}
+
+func TestEmptySecret(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewHandler(\"\") did not panic")
+		}
+	}()
+	webhook.NewHandler("")
+}
+
+func TestInsecureHandler(t *testing.T) {
+	h := webhook.NewInsecureHandler()
+	called := 0
+	h.Handle("*", func(e *webhook.Event) error {
+		called++
+		return nil
+	})
+	if w := deliver(h, "", issuesPayload); w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (%s)", w.Code, strings.TrimSpace(w.Body.String()))
+	}
+	if called != 1 {
+		t.Errorf("handler called %d times, want 1", called)
+	}
+}